@@ -2,20 +2,34 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/oriol/bumpr/internal/config"
 	"github.com/oriol/bumpr/internal/external"
 	"github.com/oriol/bumpr/internal/release"
 )
 
 var (
-	dryRun   bool
-	source   string
-	verbose  bool
-	noPush   bool
-	noCommit bool
-	quiet    bool
-	force    bool
+	dryRun        bool
+	source        string
+	sourcePattern string
+	configFile    string
+	workDir       string
+	verbose       bool
+	noPush        bool
+	noCommit      bool
+	quiet         bool
+	force         bool
+	noRollback    bool
+	yes           bool
+	replaceTag    bool
+	remote        string
+	anyBranch     bool
+	atomicPush    bool
+	sign          bool
+	signingKey    string
+	planOut       string
 )
 
 var rootCmd = &cobra.Command{
@@ -25,7 +39,40 @@ var rootCmd = &cobra.Command{
 and orchestrates git operations for creating releases.
 
 It supports multiple version source files including pyproject.toml,
-package.json, and .version files.`,
+package.json, and .version files.
+
+bumpr can be run from any subdirectory: it searches upward for the project
+root, i.e. the nearest directory holding a .bumpr.yml or the git top-level.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Like git -C, every other path argument is relative to --cwd
+		if workDir != "" {
+			if err := os.Chdir(workDir); err != nil {
+				return fmt.Errorf("failed to change directory: %w", err)
+			}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Without a bump type, offer a menu on a terminal
+		if dryRun || yes || !release.Interactive() {
+			return cmd.Help()
+		}
+		if quiet && verbose {
+			return fmt.Errorf("cannot use --quiet and --verbose together")
+		}
+
+		orchestrator, cfg, err := newOrchestrator()
+		if err != nil {
+			return err
+		}
+
+		options := releaseOptions("", cfg)
+		options.BumpType, err = orchestrator.ChooseBumpType(options)
+		if err != nil {
+			return err
+		}
+		return orchestrator.Execute(options)
+	},
 }
 
 var patchCmd = &cobra.Command{
@@ -74,21 +121,124 @@ This command will:
 	},
 }
 
+var sourcesCmd = &cobra.Command{
+	Use:   "sources",
+	Short: "List detected version sources and the one a release would use",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		orchestrator, _, err := newOrchestrator()
+		if err != nil {
+			return err
+		}
+		return orchestrator.ShowSources()
+	},
+}
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Finish an interrupted release without bumping the version again",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		orchestrator, cfg, err := newOrchestrator()
+		if err != nil {
+			return err
+		}
+		return orchestrator.Resume(releaseOptions("", cfg))
+	},
+}
+
+var abortCmd = &cobra.Command{
+	Use:   "abort",
+	Short: "Discard an interrupted release and undo its unpublished steps",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		orchestrator, cfg, err := newOrchestrator()
+		if err != nil {
+			return err
+		}
+		return orchestrator.Abort(releaseOptions("", cfg))
+	},
+}
+
+var planCmd = &cobra.Command{
+	Use:       "plan <patch|minor|major|republish>",
+	Short:     "Show the exact steps of a release, optionally saving them for 'bumpr apply'",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"patch", "minor", "major", "republish"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		orchestrator, cfg, err := newOrchestrator()
+		if err != nil {
+			return err
+		}
+
+		plan, err := orchestrator.Plan(releaseOptions(args[0], cfg))
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("📋 Release plan:")
+		fmt.Println()
+		plan.Print()
+
+		if planOut != "" {
+			if err := plan.Save(planOut); err != nil {
+				return err
+			}
+			fmt.Println()
+			fmt.Printf("💾 Saved plan to %s. Run 'bumpr apply %s' to execute it.\n", planOut, planOut)
+		}
+		return nil
+	},
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <plan.json>",
+	Short: "Execute a release plan saved with 'bumpr plan --out'",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plan, err := release.LoadPlan(args[0])
+		if err != nil {
+			return err
+		}
+
+		orchestrator, cfg, err := newOrchestrator()
+		if err != nil {
+			return err
+		}
+		return orchestrator.Apply(plan, releaseOptions(plan.BumpType, cfg))
+	},
+}
+
 func init() {
 	flags := rootCmd.PersistentFlags()
 	flags.BoolVarP(&dryRun, "dry-run", "n", false, "Preview changes without execution")
 	flags.StringVarP(&source, "source", "s", "", "Specify version source file (auto-detect if not provided)")
+	flags.StringVar(&sourcePattern, "source-pattern", "", "Regex with a (?P<version>...) group locating the version in --source")
+	flags.StringVar(&configFile, "config", "", "Path to config file (default: .bumpr.yml in the project root)")
+	flags.StringVarP(&workDir, "cwd", "C", "", "Run as if bumpr was started in this directory")
 	flags.BoolVarP(&verbose, "verbose", "v", false, "Show all executed commands")
 	flags.BoolVar(&noPush, "no-push", false, "Skip pushing tags to remote repository")
 	flags.BoolVar(&noCommit, "no-commit", false, "Skip committing changes")
 	flags.BoolVarP(&quiet, "quiet", "q", false, "Suppress non-essential output")
 	flags.BoolVarP(&force, "force", "f", false, "Skip safety checks and confirmations")
+	flags.BoolVar(&noRollback, "no-rollback", false, "Leave a failed release as is instead of undoing completed steps")
+	flags.StringVar(&remote, "remote", "", "Remote to push to (default: the upstream remote of the branch, or origin)")
+	flags.BoolVar(&atomicPush, "atomic", false, "Push the release commit and tag in one atomic push (git push --atomic)")
+	flags.BoolVar(&anyBranch, "allow-any-branch", false, "Release from the current branch even if the branch rules in the config forbid it")
+	flags.BoolVar(&sign, "sign", false, "Sign the release commit and tag (git commit -S, git tag -s)")
+	flags.StringVar(&signingKey, "signing-key", "", "Key to sign with, implies --sign (default: git's user.signingkey)")
+	flags.BoolVar(&replaceTag, "replace-tag", false, "Replace the tag of the new version if it already exists locally or on the remote")
+	flags.BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation (implied in CI and without a terminal)")
 
 	rootCmd.AddCommand(patchCmd)
 	rootCmd.AddCommand(minorCmd)
 	rootCmd.AddCommand(majorCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(republishCmd)
+	rootCmd.AddCommand(sourcesCmd)
+	rootCmd.AddCommand(resumeCmd)
+	rootCmd.AddCommand(abortCmd)
+
+	planCmd.Flags().StringVarP(&planOut, "out", "o", "", "Save the plan as JSON to this file")
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(applyCmd)
 }
 
 func getVersion() string {
@@ -108,19 +258,70 @@ func runRelease(bumpType string) error {
 		return fmt.Errorf("cannot use --quiet and --verbose together")
 	}
 
-	runner := external.NewRunner(verbose)
-	orchestrator := release.NewOrchestrator(runner, verbose)
-
-	options := release.Options{
-		BumpType: bumpType,
-		Source:   source,
-		DryRun:   dryRun,
-		Verbose:  verbose,
-		NoPush:   noPush,
-		NoCommit: noCommit,
-		Quiet:    quiet,
-		Force:    force,
+	orchestrator, cfg, err := newOrchestrator()
+	if err != nil {
+		return err
+	}
+
+	return orchestrator.Execute(releaseOptions(bumpType, cfg))
+}
+
+func releaseOptions(bumpType string, cfg *config.Config) release.Options {
+	return release.Options{
+		BumpType:       bumpType,
+		Source:         source,
+		SourcePattern:  sourcePattern,
+		DryRun:         dryRun,
+		Verbose:        verbose,
+		NoPush:         noPush,
+		NoCommit:       noCommit,
+		Quiet:          quiet,
+		Force:          force,
+		NoRollback:     noRollback,
+		Confirm:        !yes && !force && release.Interactive(),
+		ReplaceTag:     replaceTag,
+		AllowAnyBranch: anyBranch,
+		AtomicPush:     atomicPush || cfg.AtomicPush,
+		Sign:           sign || signingKey != "" || cfg.Sign,
+		SigningKey:     firstNonEmpty(signingKey, cfg.SigningKey),
+		Remote:         firstNonEmpty(remote, cfg.Remote),
+		UpstreamBranch: cfg.UpstreamBranch,
+		TagPrefix:      cfg.TagPrefix,
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func newOrchestrator() (*release.Orchestrator, *config.Config, error) {
+	root, err := config.FindRoot(".")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return orchestrator.Execute(options)
-}
\ No newline at end of file
+	runner := external.NewRunnerInDir(root, verbose)
+	orchestrator, err := release.NewOrchestrator(runner, cfg, root, verbose)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return orchestrator, cfg, nil
+}
+
+func loadConfig(root string) (*config.Config, error) {
+	if configFile != "" {
+		return config.LoadFile(configFile)
+	}
+	return config.Load(root)
+}
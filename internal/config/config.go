@@ -0,0 +1,213 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileNames lists the configuration files looked up in the project root, in order.
+var FileNames = []string{".bumpr.yml", ".bumpr.yaml"}
+
+type Config struct {
+	Sources []SourceConfig `yaml:"sources"`
+
+	// Prepended to the version to build tag names, e.g. "v" or "api/"
+	TagPrefix string `yaml:"tag_prefix"`
+
+	// Preferred order of auto-detected sources when several are found
+	Priority []string `yaml:"priority"`
+
+	// Container image references updated to the new version on release
+	Images []ImageConfig `yaml:"images"`
+
+	// Branches releases are allowed from, any branch when empty
+	Branches []BranchRule `yaml:"branches"`
+
+	// Sign the release commit and tag, with SigningKey or git's user.signingkey
+	Sign       bool   `yaml:"sign"`
+	SigningKey string `yaml:"signing_key"`
+
+	// How git is driven: "cli" runs the git binary (default), "go-git" works
+	// in-process
+	GitBackend string `yaml:"git_backend"`
+
+	// Remote to push to, by default the upstream remote of the branch or origin
+	Remote string `yaml:"remote"`
+
+	// Push the release commit and tag in one atomic push
+	AtomicPush bool `yaml:"atomic_push"`
+
+	// Remote branch the release commit is pushed to, by default the upstream
+	// branch of the current branch or one with the same name
+	UpstreamBranch string `yaml:"upstream_branch"`
+
+	// Path of the file the configuration was loaded from, empty for defaults
+	Path string `yaml:"-"`
+}
+
+type SourceConfig struct {
+	Type    string `yaml:"type"`
+	File    string `yaml:"file"`
+	Pattern string `yaml:"pattern"`
+	Search  string `yaml:"search"`
+	Replace string `yaml:"replace"`
+	Path    string `yaml:"path"`
+	Command string `yaml:"command"`
+}
+
+type ImageConfig struct {
+	// Image name as written in the files, including any registry, e.g. ghcr.io/myorg/app
+	Name string `yaml:"name"`
+
+	// Files or glob patterns relative to the project root
+	Files []string `yaml:"files"`
+
+	// Tag template using {new_version}, by default the version itself
+	Tag string `yaml:"tag"`
+}
+
+type BranchRule struct {
+	// Branch name or glob, e.g. main or release/*
+	Pattern string `yaml:"pattern"`
+
+	// Bump types allowed from matching branches, all when empty
+	Bump []string `yaml:"bump"`
+}
+
+// Matches reports whether branch matches the rule's pattern.
+func (r BranchRule) Matches(branch string) bool {
+	matched, err := path.Match(r.Pattern, branch)
+	return err == nil && matched
+}
+
+// Allows reports whether the rule applies to bumpType releases.
+func (r BranchRule) Allows(bumpType string) bool {
+	if len(r.Bump) == 0 {
+		return true
+	}
+	for _, allowed := range r.Bump {
+		if allowed == bumpType {
+			return true
+		}
+	}
+	return false
+}
+
+func Default() *Config {
+	return &Config{}
+}
+
+// Find returns the path of the configuration file in dir, or "" if there is none.
+func Find(dir string) string {
+	for _, name := range FileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// Load reads the configuration from dir, falling back to defaults when no
+// configuration file exists.
+func Load(dir string) (*Config, error) {
+	path := Find(dir)
+	if path == "" {
+		return Default(), nil
+	}
+	return LoadFile(path)
+}
+
+func LoadFile(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	cfg := Default()
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", filepath.Base(path), err)
+	}
+	cfg.Path = path
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", filepath.Base(path), err)
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) Validate() error {
+	for i, source := range c.Sources {
+		if source.File == "" && source.Type != "git-tag" && source.Type != "plugin" {
+			return fmt.Errorf("sources[%d]: file is required", i)
+		}
+
+		switch source.Type {
+		case "pattern":
+			if source.Pattern == "" && source.Search == "" {
+				return fmt.Errorf("sources[%d]: pattern or search is required", i)
+			}
+			if source.Pattern != "" && source.Search != "" {
+				return fmt.Errorf("sources[%d]: pattern and search are mutually exclusive", i)
+			}
+		case "path":
+			if source.Path == "" {
+				return fmt.Errorf("sources[%d]: path is required", i)
+			}
+		case "git-tag":
+		case "plugin":
+			if source.Command == "" {
+				return fmt.Errorf("sources[%d]: command is required", i)
+			}
+		case "":
+			return fmt.Errorf("sources[%d]: type is required", i)
+		default:
+			return fmt.Errorf("sources[%d]: unknown source type %q", i, source.Type)
+		}
+	}
+
+	for i, image := range c.Images {
+		if image.Name == "" {
+			return fmt.Errorf("images[%d]: name is required", i)
+		}
+		if len(image.Files) == 0 {
+			return fmt.Errorf("images[%d]: files is required", i)
+		}
+		// A colon is only allowed in the registry part, e.g. localhost:5000/app
+		if repository := image.Name[strings.LastIndex(image.Name, "/")+1:]; strings.ContainsAny(repository, ":@") {
+			return fmt.Errorf("images[%d]: name must not include a tag or digest", i)
+		}
+		if image.Tag != "" && !strings.Contains(image.Tag, "{new_version}") {
+			return fmt.Errorf("images[%d]: tag must contain {new_version}", i)
+		}
+	}
+
+	for i, rule := range c.Branches {
+		if rule.Pattern == "" {
+			return fmt.Errorf("branches[%d]: pattern is required", i)
+		}
+		if _, err := path.Match(rule.Pattern, ""); err != nil {
+			return fmt.Errorf("branches[%d]: invalid pattern %q", i, rule.Pattern)
+		}
+		for _, bumpType := range rule.Bump {
+			switch bumpType {
+			case "major", "minor", "patch", "republish":
+			default:
+				return fmt.Errorf("branches[%d]: unknown bump type %q", i, bumpType)
+			}
+		}
+	}
+
+	switch c.GitBackend {
+	case "", "cli", "go-git":
+	default:
+		return fmt.Errorf("git_backend: unknown backend %q, use cli or go-git", c.GitBackend)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindRoot(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := filepath.Join(tmpDir, "repo")
+	nested := filepath.Join(repo, "src", "pkg")
+	module := filepath.Join(repo, "modules", "api")
+	for _, dir := range []string{filepath.Join(repo, ".git"), nested, filepath.Join(module, "internal")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(module, ".bumpr.yml"), []byte("tag_prefix: api/\n"), 0644); err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		start string
+		want  string
+	}{
+		{name: "git top-level from subdirectory", start: nested, want: repo},
+		{name: "git top-level itself", start: repo, want: repo},
+		{name: "nearest config file wins", start: filepath.Join(module, "internal"), want: module},
+		{name: "no marker keeps start", start: tmpDir, want: tmpDir},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindRoot(tt.start)
+			if err != nil {
+				t.Fatalf("FindRoot() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FindRoot() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load() without config error = %v", err)
+	}
+	if cfg.Path != "" || len(cfg.Sources) != 0 {
+		t.Errorf("Load() without config = %+v, want defaults", cfg)
+	}
+
+	content := `tag_prefix: v
+remote: upstream
+upstream_branch: stable
+atomic_push: true
+git_backend: go-git
+sign: true
+signing_key: ~/.ssh/release.pub
+sources:
+  - type: pattern
+    file: Dockerfile
+    pattern: 'version="(?P<version>[^"]+)"'
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".bumpr.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	cfg, err = Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.TagPrefix != "v" || cfg.Remote != "upstream" || cfg.UpstreamBranch != "stable" || !cfg.AtomicPush || cfg.GitBackend != "go-git" || !cfg.Sign || cfg.SigningKey != "~/.ssh/release.pub" || len(cfg.Sources) != 1 || cfg.Sources[0].File != "Dockerfile" {
+		t.Errorf("Load() = %+v", cfg)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, ".bumpr.yml"), []byte("sources:\n  - type: regex\n    file: x\n"), 0644); err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	if _, err := Load(tmpDir); err == nil {
+		t.Error("Load() with an unknown source type should fail")
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, ".bumpr.yml"), []byte("git_backend: libgit2\n"), 0644); err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	if _, err := Load(tmpDir); err == nil {
+		t.Error("Load() with an unknown git backend should fail")
+	}
+}
+
+func TestValidate_Images(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   ImageConfig
+		wantErr bool
+	}{
+		{name: "valid", image: ImageConfig{Name: "myorg/app", Files: []string{"docker-compose.yml"}}},
+		{name: "registry with port", image: ImageConfig{Name: "localhost:5000/app", Files: []string{"k8s/*.yaml"}}},
+		{name: "missing files", image: ImageConfig{Name: "myorg/app"}, wantErr: true},
+		{name: "missing name", image: ImageConfig{Files: []string{"Dockerfile"}}, wantErr: true},
+		{name: "name with tag", image: ImageConfig{Name: "myorg/app:1.0.0", Files: []string{"Dockerfile"}}, wantErr: true},
+		{name: "tag template", image: ImageConfig{Name: "myorg/app", Files: []string{"Dockerfile"}, Tag: "{new_version}-alpine"}},
+		{name: "tag without version", image: ImageConfig{Name: "myorg/app", Files: []string{"Dockerfile"}, Tag: "latest"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Images: []ImageConfig{tt.image}}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Branches(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    BranchRule
+		wantErr bool
+	}{
+		{name: "any bump", rule: BranchRule{Pattern: "main"}},
+		{name: "glob with bump types", rule: BranchRule{Pattern: "hotfix/*", Bump: []string{"patch", "republish"}}},
+		{name: "missing pattern", rule: BranchRule{Bump: []string{"patch"}}, wantErr: true},
+		{name: "invalid pattern", rule: BranchRule{Pattern: "release/[1-"}, wantErr: true},
+		{name: "unknown bump type", rule: BranchRule{Pattern: "main", Bump: []string{"hotfix"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Branches: []BranchRule{tt.rule}}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBranchRule_Matches(t *testing.T) {
+	rule := BranchRule{Pattern: "release/*"}
+
+	for branch, want := range map[string]bool{
+		"release/1.x":   true,
+		"release/2024":  true,
+		"release":       false,
+		"release/1/fix": false,
+		"main":          false,
+	} {
+		if got := rule.Matches(branch); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", branch, got, want)
+		}
+	}
+}
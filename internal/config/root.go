@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// FindRoot walks up from start to the nearest directory holding a bumpr
+// config file or a .git entry (a directory, or a file for worktrees and
+// submodules). When neither is found start itself is returned.
+func FindRoot(start string) (string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+
+	for current := dir; ; {
+		if Find(current) != "" {
+			return current, nil
+		}
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current, nil
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir, nil
+		}
+		current = parent
+	}
+}
@@ -0,0 +1,58 @@
+package external
+
+import "fmt"
+
+// GitBackend is the set of git operations a release needs. GitCommands runs
+// the git binary, GoGitBackend performs them in-process.
+type GitBackend interface {
+	Add(files ...string) error
+	Commit(message string) error
+	CommitSigned(message, key string) error
+	CreateTag(tagName, message string) error
+	CreateSignedTag(tagName, message, key string) error
+	VerifyTag(tagName string) error
+	Push(remote, branch, target string) error
+	PushTag(remote, tagName string) error
+	PushAtomic(remote, branch, target, tagName string) error
+	DeleteLocalTag(tagName string) error
+	DeleteRemoteTag(remote, tagName string) error
+	FetchTag(remote, tagName string) error
+	Status() (string, error)
+	IsClean() (bool, error)
+	CurrentBranch() (string, error)
+	IsRepository() bool
+	TagExists(tagName string) bool
+	LocalTag(tagName string) (object, commit string, ok bool)
+	RemoteTag(remote, tagName string) (object, commit string, ok bool, err error)
+	RestoreTag(tagName, object string) error
+	RestoreRemoteTag(remote, tagName, object string) error
+	HasRemote(remote string) bool
+	Divergence(remote, branch string) (ahead, behind int, found bool, err error)
+	Upstream(branch string) (remote, remoteBranch string)
+	Head() (string, error)
+	Reset(commit string) error
+	GitDir() (string, error)
+	RestoreFiles(commit string, files ...string) error
+	MergedTags(prefix string) ([]string, error)
+	CommitSubjects(since string) ([]string, error)
+}
+
+// Git backends selectable with git_backend in the config file.
+const (
+	BackendCLI   = "cli"
+	BackendGoGit = "go-git"
+)
+
+// NewGitBackend returns the backend called name for the repository holding
+// dir, the git binary when name is empty. runner is expected to execute
+// commands in dir.
+func NewGitBackend(name string, runner CommandRunner, dir string, verbose bool) (GitBackend, error) {
+	switch name {
+	case "", BackendCLI:
+		return NewGitCommands(runner, verbose), nil
+	case BackendGoGit:
+		return NewGoGitBackend(dir, verbose), nil
+	default:
+		return nil, fmt.Errorf("unknown git backend %q, use %s or %s", name, BackendCLI, BackendGoGit)
+	}
+}
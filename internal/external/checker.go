@@ -3,16 +3,22 @@ package external
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 )
 
 type DependencyChecker struct {
 	runner CommandRunner
+	git    GitBackend
 }
 
-func NewDependencyChecker(runner CommandRunner) *DependencyChecker {
+// NewDependencyChecker checks the repository through git and the other tools
+// through runner.
+func NewDependencyChecker(runner CommandRunner, git GitBackend) *DependencyChecker {
 	return &DependencyChecker{
 		runner: runner,
+		git:    git,
 	}
 }
 
@@ -33,20 +39,19 @@ func (d *DependencyChecker) CheckGitHub() error {
 }
 
 func (d *DependencyChecker) CheckRepository() error {
-	_, err := d.runner.Run(context.Background(), "git", "rev-parse", "--git-dir")
-	if err != nil {
+	if !d.git.IsRepository() {
 		return fmt.Errorf("not in a git repository")
 	}
 	return nil
 }
 
 func (d *DependencyChecker) CheckWorkingDirectory() error {
-	result, err := d.runner.RunWithOutput(context.Background(), "git", "status", "--porcelain")
+	clean, err := d.git.IsClean()
 	if err != nil {
 		return err
 	}
 	
-	if strings.TrimSpace(result.Stdout) != "" {
+	if !clean {
 		return fmt.Errorf("working directory is not clean. Please commit or stash changes")
 	}
 	
@@ -54,9 +59,11 @@ func (d *DependencyChecker) CheckWorkingDirectory() error {
 }
 
 func (d *DependencyChecker) RunPreflightChecks(skipCleanCheck bool) error {
-	// Check git is available
-	if err := d.CheckGit(); err != nil {
-		return err
+	// Check git is available, unless the backend does without it
+	if d.usesGitBinary() {
+		if err := d.CheckGit(); err != nil {
+			return err
+		}
 	}
 
 	// Check we're in a git repository
@@ -64,6 +71,11 @@ func (d *DependencyChecker) RunPreflightChecks(skipCleanCheck bool) error {
 		return err
 	}
 
+	// Every release commits or tags, which needs a branch
+	if _, err := d.CheckBranch(); err != nil {
+		return err
+	}
+
 	// Check working directory is clean
 	if !skipCleanCheck {
 		if err := d.CheckWorkingDirectory(); err != nil {
@@ -75,4 +87,92 @@ func (d *DependencyChecker) RunPreflightChecks(skipCleanCheck bool) error {
 	// We'll just note it for later
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// CheckBranch returns the current branch, refusing a detached HEAD.
+func (d *DependencyChecker) CheckBranch() (string, error) {
+	branch, err := d.git.CurrentBranch()
+	if err != nil || branch == "HEAD" {
+		return "", fmt.Errorf("HEAD is detached. Check out the branch to release from")
+	}
+	return branch, nil
+}
+
+// CheckUpstream fetches branch from remote and refuses a release when HEAD is
+// behind it or has diverged from it. It returns the number of local commits
+// the release will push along.
+func (d *DependencyChecker) CheckUpstream(remote, branch string) (int, error) {
+	if !d.git.HasRemote(remote) {
+		return 0, fmt.Errorf("remote %s does not exist. Add it or pick another one with --remote", remote)
+	}
+
+	ahead, behind, found, err := d.git.Divergence(remote, branch)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		// A new branch, the release creates it
+		return 0, nil
+	}
+
+	switch {
+	case behind > 0 && ahead > 0:
+		return 0, fmt.Errorf("HEAD has diverged from %s/%s (%d local and %d remote commits). Rebase or merge before releasing", remote, branch, ahead, behind)
+	case behind > 0:
+		return 0, fmt.Errorf("HEAD is %d commit(s) behind %s/%s. Pull before releasing", behind, remote, branch)
+	}
+	return ahead, nil
+}
+
+// CheckSigningKey checks that git can sign with key, or with user.signingkey
+// when key is empty.
+func (d *DependencyChecker) CheckSigningKey(key string) error {
+	if !d.usesGitBinary() {
+		return fmt.Errorf("signing is only supported by the %s git backend", BackendCLI)
+	}
+	if key == "" {
+		key = d.gitConfig("user.signingkey")
+	}
+	if key == "" {
+		return fmt.Errorf("signing is enabled but no signing key is configured. Set git's user.signingkey, signing_key in .bumpr.yml or pass --signing-key")
+	}
+
+	switch format := d.gitConfig("gpg.format"); format {
+	case "", "openpgp":
+		program := d.gitConfig("gpg.program")
+		if program == "" {
+			program = "gpg"
+		}
+		if _, err := d.runner.Run(context.Background(), program, "--list-secret-keys", key); err != nil {
+			return fmt.Errorf("no secret GPG key %s found for signing", key)
+		}
+	case "ssh":
+		// Literal keys and keys held by an agent cannot be checked here
+		path := key
+		if strings.HasPrefix(path, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, path[2:])
+			}
+		}
+		if filepath.IsAbs(path) {
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("SSH signing key %s not found", key)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *DependencyChecker) usesGitBinary() bool {
+	_, cli := d.git.(*GitCommands)
+	return cli
+}
+
+func (d *DependencyChecker) gitConfig(key string) string {
+	result, err := d.runner.RunWithOutput(context.Background(), "git", "config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(result.Stdout)
+}
@@ -2,6 +2,8 @@ package external
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -35,36 +37,126 @@ func (g *GitCommands) CreateTag(tagName, message string) error {
 	return err
 }
 
-func (g *GitCommands) PushTag(tagName string) error {
-	args := []string{"push", "origin", tagName}
+// CommitSigned commits like Commit with a signature made with key, or with
+// user.signingkey when key is empty.
+func (g *GitCommands) CommitSigned(message, key string) error {
+	args := []string{"commit", "-S" + key, "-m", message}
 	_, err := g.runner.Run(context.Background(), "git", args...)
 	return err
 }
 
-func (g *GitCommands) PushTagWithForce(tagName string) error {
-	args := []string{"push", "origin", tagName, "--force"}
+// CreateSignedTag creates a tag signed with key, or with user.signingkey
+// when key is empty.
+func (g *GitCommands) CreateSignedTag(tagName, message, key string) error {
+	args := []string{"tag", "-s"}
+	if key != "" {
+		args = append(args, "-u", key)
+	}
+	args = append(args, tagName, "-m", message)
 	_, err := g.runner.Run(context.Background(), "git", args...)
 	return err
 }
 
-func (g *GitCommands) Push(branch string) error {
-	args := []string{"push", "origin", branch}
+func (g *GitCommands) VerifyTag(tagName string) error {
+	_, err := g.runner.Run(context.Background(), "git", "verify-tag", tagName)
+	return err
+}
+
+func (g *GitCommands) PushTag(remote, tagName string) error {
+	args := []string{"push", remote, tagName}
 	_, err := g.runner.Run(context.Background(), "git", args...)
 	return err
 }
 
+// Push pushes branch to remote, as target when the remote branch has another name.
+func (g *GitCommands) Push(remote, branch, target string) error {
+	args := []string{"push", remote, PushRefspec(branch, target)}
+	_, err := g.runner.Run(context.Background(), "git", args...)
+	return err
+}
+
+// ErrAtomicUnsupported is returned by PushAtomic when the remote cannot take
+// an atomic push. Nothing has been pushed then.
+var ErrAtomicUnsupported = errors.New("the remote does not support atomic pushes")
+
+// PushAtomic pushes branch (as target) and tagName in a single push that the
+// remote applies entirely or not at all.
+func (g *GitCommands) PushAtomic(remote, branch, target, tagName string) error {
+	args := []string{"push", "--atomic", remote, PushRefspec(branch, target), tagName}
+
+	// The refusal is only told apart by its message, which git translates
+	var result *CommandResult
+	var err error
+	if runner, ok := g.runner.(EnvRunner); ok {
+		result, err = runner.RunWithEnv(context.Background(), []string{"LC_ALL=C"}, "git", args...)
+	} else {
+		result, err = g.runner.RunWithOutput(context.Background(), "git", args...)
+	}
+	if err == nil || result == nil {
+		return err
+	}
+	if strings.Contains(result.Stderr, "does not support --atomic") {
+		return ErrAtomicUnsupported
+	}
+	return commandError(err, result)
+}
+
+// commandError keeps the reason git gave for a failure whose output was
+// captured.
+func commandError(err error, result *CommandResult) error {
+	if err != nil && result != nil {
+		if reason := strings.TrimSpace(result.Stderr); reason != "" {
+			return fmt.Errorf("%w\n%s", err, reason)
+		}
+	}
+	return err
+}
+
+func PushRefspec(branch, target string) string {
+	if target == "" || target == branch {
+		return branch
+	}
+	return branch + ":" + target
+}
+
+// DeleteLocalTag deletes tagName. A tag that does not exist is not an error,
+// so undoing a release can be repeated.
 func (g *GitCommands) DeleteLocalTag(tagName string) error {
-	args := []string{"tag", "-d", tagName}
-	// Ignore errors for this operation
-	g.runner.Run(context.Background(), "git", args...)
-	return nil
+	result, err := g.runner.RunWithOutput(context.Background(), "git", "tag", "-d", tagName)
+	if err == nil {
+		return nil
+	}
+	if _, _, ok := g.LocalTag(tagName); !ok {
+		return nil
+	}
+	return commandError(err, result)
 }
 
-func (g *GitCommands) DeleteRemoteTag(tagName string) error {
-	args := []string{"push", "origin", "--delete", tagName}
-	// Ignore errors for this operation
-	g.runner.Run(context.Background(), "git", args...)
-	return nil
+// DeleteRemoteTag deletes tagName on remote. Like DeleteLocalTag, a tag that
+// does not exist is not an error.
+func (g *GitCommands) DeleteRemoteTag(remote, tagName string) error {
+	result, err := g.runner.RunWithOutput(context.Background(), "git", "push", remote, "--delete", tagName)
+	if err == nil {
+		return nil
+	}
+	if _, _, ok, lookupErr := g.RemoteTag(remote, tagName); lookupErr == nil && !ok {
+		return nil
+	}
+	return commandError(err, result)
+}
+
+// FetchTag downloads the objects of tagName on remote without creating any
+// local ref, so the tag can be pushed back by object once deleted.
+func (g *GitCommands) FetchTag(remote, tagName string) error {
+	result, err := g.runner.RunWithOutput(context.Background(), "git", "fetch", "--no-tags", remote, "refs/tags/"+tagName)
+	return commandError(err, result)
+}
+
+// RestoreRemoteTag points tagName on remote at object again, e.g. after it
+// was deleted. The object has to be available locally, see FetchTag.
+func (g *GitCommands) RestoreRemoteTag(remote, tagName, object string) error {
+	result, err := g.runner.RunWithOutput(context.Background(), "git", "push", remote, object+":refs/tags/"+tagName)
+	return commandError(err, result)
 }
 
 func (g *GitCommands) Status() (string, error) {
@@ -99,4 +191,165 @@ func (g *GitCommands) IsRepository() bool {
 func (g *GitCommands) TagExists(tagName string) bool {
 	_, err := g.runner.Run(context.Background(), "git", "rev-parse", tagName)
 	return err == nil
-}
\ No newline at end of file
+}
+
+// LocalTag returns the object a local tag points at, which is the tag object
+// for annotated tags, and the commit it tags. ok is false when there is no
+// such tag.
+func (g *GitCommands) LocalTag(tagName string) (object, commit string, ok bool) {
+	result, err := g.runner.RunWithOutput(context.Background(), "git", "rev-parse", "-q", "--verify", "refs/tags/"+tagName)
+	if err != nil {
+		return "", "", false
+	}
+	object = strings.TrimSpace(result.Stdout)
+
+	result, err = g.runner.RunWithOutput(context.Background(), "git", "rev-parse", "-q", "--verify", "refs/tags/"+tagName+"^{commit}")
+	if err != nil {
+		return object, "", true
+	}
+	return object, strings.TrimSpace(result.Stdout), true
+}
+
+// RemoteTag returns the object a tag on remote points at and the commit it
+// tags, like LocalTag. ok is false when remote has no such tag.
+func (g *GitCommands) RemoteTag(remote, tagName string) (object, commit string, ok bool, err error) {
+	ref := "refs/tags/" + tagName
+	result, err := g.runner.RunWithOutput(context.Background(), "git", "ls-remote", "--tags", remote, ref, ref+"^{}")
+	if err != nil {
+		return "", "", false, err
+	}
+
+	var peeled string
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[1] {
+		case ref + "^{}":
+			// The peeled commit of an annotated tag
+			peeled = fields[0]
+		case ref:
+			object, ok = fields[0], true
+		}
+	}
+
+	commit = object
+	if peeled != "" {
+		commit = peeled
+	}
+	return object, commit, ok, nil
+}
+
+// RestoreTag points tagName at object again, e.g. after it was deleted.
+func (g *GitCommands) RestoreTag(tagName, object string) error {
+	_, err := g.runner.Run(context.Background(), "git", "update-ref", "refs/tags/"+tagName, object)
+	return err
+}
+
+// HasRemote reports whether remote is configured.
+func (g *GitCommands) HasRemote(remote string) bool {
+	_, err := g.runner.Run(context.Background(), "git", "remote", "get-url", remote)
+	return err == nil
+}
+
+// Divergence fetches branch from remote and counts the commits only HEAD has
+// and the ones only the remote branch has. found is false when remote has no
+// such branch.
+func (g *GitCommands) Divergence(remote, branch string) (ahead, behind int, found bool, err error) {
+	ref := "refs/heads/" + branch
+	result, err := g.runner.RunWithOutput(context.Background(), "git", "ls-remote", "--heads", remote, ref)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("failed to reach remote %s: %w", remote, err)
+	}
+	if strings.TrimSpace(result.Stdout) == "" {
+		return 0, 0, false, nil
+	}
+
+	if _, err := g.runner.Run(context.Background(), "git", "fetch", "--quiet", remote, ref); err != nil {
+		return 0, 0, true, fmt.Errorf("failed to fetch %s from %s: %w", branch, remote, err)
+	}
+
+	result, err = g.runner.RunWithOutput(context.Background(), "git", "rev-list", "--left-right", "--count", "HEAD...FETCH_HEAD")
+	if err != nil {
+		return 0, 0, true, fmt.Errorf("failed to compare with %s/%s: %w", remote, branch, err)
+	}
+	if _, err := fmt.Sscan(result.Stdout, &ahead, &behind); err != nil {
+		return 0, 0, true, fmt.Errorf("failed to compare with %s/%s: unexpected output %q", remote, branch, result.Stdout)
+	}
+	return ahead, behind, true, nil
+}
+
+// Upstream returns the remote and remote branch that branch tracks, empty
+// when it tracks none.
+func (g *GitCommands) Upstream(branch string) (remote, remoteBranch string) {
+	if result, err := g.runner.RunWithOutput(context.Background(), "git", "config", "--get", "branch."+branch+".remote"); err == nil {
+		remote = strings.TrimSpace(result.Stdout)
+	}
+	if result, err := g.runner.RunWithOutput(context.Background(), "git", "config", "--get", "branch."+branch+".merge"); err == nil {
+		remoteBranch = strings.TrimPrefix(strings.TrimSpace(result.Stdout), "refs/heads/")
+	}
+	return remote, remoteBranch
+}
+
+// Head returns the commit HEAD points at.
+func (g *GitCommands) Head() (string, error) {
+	result, err := g.runner.RunWithOutput(context.Background(), "git", "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// Reset moves the current branch back to commit, keeping the working tree.
+func (g *GitCommands) Reset(commit string) error {
+	_, err := g.runner.Run(context.Background(), "git", "reset", "--quiet", commit)
+	return err
+}
+
+// GitDir returns the absolute path of the repository's .git directory.
+func (g *GitCommands) GitDir() (string, error) {
+	result, err := g.runner.RunWithOutput(context.Background(), "git", "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// RestoreFiles checks out files as they were at commit, in the index and the
+// working tree.
+func (g *GitCommands) RestoreFiles(commit string, files ...string) error {
+	args := append([]string{"checkout", commit, "--"}, files...)
+	_, err := g.runner.Run(context.Background(), "git", args...)
+	return err
+}
+
+func (g *GitCommands) MergedTags(prefix string) ([]string, error) {
+	result, err := g.runner.RunWithOutput(context.Background(), "git", "tag", "--list", prefix+"*", "--merged", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(result.Stdout), nil
+}
+
+// CommitSubjects lists the subjects of the commits reachable from HEAD but not
+// from since, newest first. An empty since lists the whole history.
+func (g *GitCommands) CommitSubjects(since string) ([]string, error) {
+	args := []string{"log", "--no-merges", "--format=%s"}
+	if since != "" {
+		args = append(args, since+"..HEAD")
+	}
+
+	result, err := g.runner.RunWithOutput(context.Background(), "git", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var subjects []string
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			subjects = append(subjects, line)
+		}
+	}
+	return subjects, nil
+}
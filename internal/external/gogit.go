@@ -0,0 +1,829 @@
+package external
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+var errSigningUnsupported = fmt.Errorf("signing is only supported by the %s git backend", BackendCLI)
+
+// GoGitBackend performs the git operations of a release in-process with
+// go-git, without spawning git or parsing its output. Only local file remotes
+// still go through git-upload-pack and git-receive-pack.
+type GoGitBackend struct {
+	dir     string
+	verbose bool
+	repo    *git.Repository
+}
+
+// NewGoGitBackend returns a backend for the repository holding dir. The
+// repository is opened on first use.
+func NewGoGitBackend(dir string, verbose bool) *GoGitBackend {
+	return &GoGitBackend{dir: dir, verbose: verbose}
+}
+
+func (g *GoGitBackend) open() (*git.Repository, error) {
+	if g.repo != nil {
+		return g.repo, nil
+	}
+
+	repo, err := git.PlainOpenWithOptions(g.dir, &git.PlainOpenOptions{DetectDotGit: true, EnableDotGitCommonDir: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	g.repo = repo
+	return repo, nil
+}
+
+func (g *GoGitBackend) worktree() (*git.Worktree, error) {
+	repo, err := g.open()
+	if err != nil {
+		return nil, err
+	}
+	return repo.Worktree()
+}
+
+// log prints the operation like the runner prints commands in verbose mode.
+func (g *GoGitBackend) log(format string, args ...any) {
+	if g.verbose {
+		fmt.Printf("→ go-git "+format+"\n", args...)
+	}
+}
+
+// relativePath turns a path relative to dir, or absolute, into one relative
+// to the top of the worktree.
+func (g *GoGitBackend) relativePath(wt *git.Worktree, file string) (string, error) {
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(g.dir, file)
+	}
+	rel, err := filepath.Rel(wt.Filesystem.Root(), file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside the repository", file)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func (g *GoGitBackend) Add(files ...string) error {
+	g.log("add %s", strings.Join(files, " "))
+	wt, err := g.worktree()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		rel, err := g.relativePath(wt, file)
+		if err != nil {
+			return err
+		}
+		if _, err := wt.Add(rel); err != nil {
+			return fmt.Errorf("failed to add %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func (g *GoGitBackend) Commit(message string) error {
+	g.log("commit -m %q", message)
+	wt, err := g.worktree()
+	if err != nil {
+		return err
+	}
+
+	author, err := g.signature("AUTHOR")
+	if err != nil {
+		return err
+	}
+	committer, err := g.signature("COMMITTER")
+	if err != nil {
+		return err
+	}
+
+	_, err = wt.Commit(message+"\n", &git.CommitOptions{Author: author, Committer: committer})
+	return err
+}
+
+func (g *GoGitBackend) CommitSigned(message, key string) error {
+	return errSigningUnsupported
+}
+
+func (g *GoGitBackend) CreateTag(tagName, message string) error {
+	g.log("tag -a %s -m %q", tagName, message)
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+	tagger, err := g.signature("COMMITTER")
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.CreateTag(tagName, head.Hash(), &git.CreateTagOptions{Tagger: tagger, Message: message})
+	return err
+}
+
+func (g *GoGitBackend) CreateSignedTag(tagName, message, key string) error {
+	return errSigningUnsupported
+}
+
+func (g *GoGitBackend) VerifyTag(tagName string) error {
+	return errSigningUnsupported
+}
+
+func (g *GoGitBackend) Push(remote, branch, target string) error {
+	if target == "" {
+		target = branch
+	}
+	return g.push(remote, false, "refs/heads/"+branch+":refs/heads/"+target)
+}
+
+func (g *GoGitBackend) PushTag(remote, tagName string) error {
+	ref := "refs/tags/" + tagName
+	return g.push(remote, false, ref+":"+ref)
+}
+
+// PushAtomic pushes branch (as target) and tagName in a single push that the
+// remote applies entirely or not at all.
+func (g *GoGitBackend) PushAtomic(remote, branch, target, tagName string) error {
+	// go-git quietly pushes non-atomically to remotes that cannot do better
+	atomic, err := g.supportsAtomic(remote)
+	if err != nil {
+		return err
+	}
+	if !atomic {
+		return ErrAtomicUnsupported
+	}
+
+	if target == "" {
+		target = branch
+	}
+	tag := "refs/tags/" + tagName
+	return g.push(remote, true, "refs/heads/"+branch+":refs/heads/"+target, tag+":"+tag)
+}
+
+// DeleteLocalTag deletes tagName. A tag that does not exist is not an error,
+// so undoing a release can be repeated.
+func (g *GoGitBackend) DeleteLocalTag(tagName string) error {
+	g.log("tag -d %s", tagName)
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+	if err := repo.DeleteTag(tagName); err != nil && !errors.Is(err, git.ErrTagNotFound) {
+		return err
+	}
+	return nil
+}
+
+// DeleteRemoteTag deletes tagName on remote. Like DeleteLocalTag, a tag that
+// does not exist is not an error.
+func (g *GoGitBackend) DeleteRemoteTag(remote, tagName string) error {
+	_, _, ok, err := g.RemoteTag(remote, tagName)
+	if err != nil || !ok {
+		return err
+	}
+	return g.push(remote, false, ":refs/tags/"+tagName)
+}
+
+// FetchTag downloads the objects of tagName on remote without creating any
+// local ref, so the tag can be pushed back by object once deleted.
+func (g *GoGitBackend) FetchTag(name, tagName string) error {
+	g.log("fetch %s refs/tags/%s", name, tagName)
+	remote, err := g.remote(name)
+	if err != nil {
+		return err
+	}
+
+	// go-git only fetches into a ref, use a scratch one
+	scratch := plumbing.ReferenceName("refs/bumpr/fetch/" + tagName)
+	err = remote.Fetch(&git.FetchOptions{
+		RefSpecs: []config.RefSpec{config.RefSpec("+refs/tags/" + tagName + ":" + scratch.String())},
+		Tags:     git.NoTags,
+		Auth:     g.auth(remote),
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return withAuthHint(err)
+	}
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+	return repo.Storer.RemoveReference(scratch)
+}
+
+// RestoreRemoteTag points tagName on remote at object again, e.g. after it
+// was deleted. The object has to be available locally, see FetchTag.
+func (g *GoGitBackend) RestoreRemoteTag(remote, tagName, object string) error {
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+
+	// go-git only pushes refs, use a scratch one
+	scratch := plumbing.ReferenceName("refs/bumpr/restore/" + tagName)
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(scratch, plumbing.NewHash(object))); err != nil {
+		return err
+	}
+	defer repo.Storer.RemoveReference(scratch)
+
+	return g.push(remote, false, scratch.String()+":refs/tags/"+tagName)
+}
+
+func (g *GoGitBackend) push(name string, atomic bool, refspecs ...string) error {
+	g.log("push %s %s", name, strings.Join(refspecs, " "))
+	remote, err := g.remote(name)
+	if err != nil {
+		return err
+	}
+
+	options := &git.PushOptions{RemoteName: remote.Config().Name, Atomic: atomic, Auth: g.auth(remote)}
+	for _, refspec := range refspecs {
+		options.RefSpecs = append(options.RefSpecs, config.RefSpec(refspec))
+	}
+	if err := g.checkTagsAbsent(name, options.RefSpecs); err != nil {
+		return err
+	}
+
+	err = remote.Push(options)
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	return withAuthHint(err)
+}
+
+// auth returns the credentials for an HTTPS remote: the token in
+// BUMPR_GIT_TOKEN, or for GitHub the one in GH_TOKEN or GITHUB_TOKEN like gh.
+// Credentials in the URL are used as they are, and go-git authenticates SSH
+// remotes through the SSH agent by itself.
+func (g *GoGitBackend) auth(remote *git.Remote) transport.AuthMethod {
+	endpoint, err := transport.NewEndpoint(remote.Config().URLs[0])
+	if err != nil || endpoint.Protocol != "https" || endpoint.Password != "" {
+		return nil
+	}
+
+	variables := []string{"BUMPR_GIT_TOKEN"}
+	if endpoint.Host == "github.com" {
+		variables = append(variables, "GH_TOKEN", "GITHUB_TOKEN")
+	}
+	for _, variable := range variables {
+		if token := os.Getenv(variable); token != "" {
+			// Hosts take any user name along with a token
+			user := endpoint.User
+			if user == "" {
+				user = "x-access-token"
+			}
+			return &githttp.BasicAuth{Username: user, Password: token}
+		}
+	}
+	return nil
+}
+
+// withAuthHint tells how to pass credentials when a remote asks for them.
+func withAuthHint(err error) error {
+	if errors.Is(err, transport.ErrAuthenticationRequired) || errors.Is(err, transport.ErrAuthorizationFailed) {
+		return fmt.Errorf("%w. The %s backend reads the token for HTTPS remotes from BUMPR_GIT_TOKEN, or GH_TOKEN/GITHUB_TOKEN for GitHub", err, BackendGoGit)
+	}
+	return err
+}
+
+// checkTagsAbsent refuses to move a tag that exists on remote, which git only
+// does when forced but go-git does quietly.
+func (g *GoGitBackend) checkTagsAbsent(name string, refspecs []config.RefSpec) error {
+	var tags []config.RefSpec
+	for _, refspec := range refspecs {
+		if refspec.Src() != "" && refspec.Dst("").IsTag() {
+			tags = append(tags, refspec)
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+	refs, err := g.list(name)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		local, err := repo.Reference(plumbing.ReferenceName(tag.Src()), true)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			if ref.Name() == tag.Dst("") && ref.Hash() != local.Hash() {
+				return fmt.Errorf("tag %s already exists on %s (rejected)", ref.Name().Short(), name)
+			}
+		}
+	}
+	return nil
+}
+
+// remote returns the remote called name, or one for the URL name when no
+// remote has that name. Relative paths are resolved against dir, as git does.
+func (g *GoGitBackend) remote(name string) (*git.Remote, error) {
+	repo, err := g.open()
+	if err != nil {
+		return nil, err
+	}
+
+	remoteConfig := &config.RemoteConfig{Name: name, URLs: []string{name}}
+	if remote, err := repo.Remote(name); err == nil {
+		remoteConfig = remote.Config()
+	}
+
+	var urls []string
+	for _, url := range remoteConfig.URLs {
+		if !filepath.IsAbs(url) && !strings.Contains(url, ":") {
+			url = filepath.Join(g.dir, url)
+		}
+		urls = append(urls, url)
+	}
+	return git.NewRemote(repo.Storer, &config.RemoteConfig{Name: remoteConfig.Name, URLs: urls, Fetch: remoteConfig.Fetch}), nil
+}
+
+// supportsAtomic asks remote whether it accepts atomic pushes.
+func (g *GoGitBackend) supportsAtomic(name string) (bool, error) {
+	remote, err := g.remote(name)
+	if err != nil {
+		return false, err
+	}
+	urls := remote.Config().URLs
+	endpoint, err := transport.NewEndpoint(urls[len(urls)-1])
+	if err != nil {
+		return false, err
+	}
+	transporter, err := client.NewClient(endpoint)
+	if err != nil {
+		return false, err
+	}
+
+	session, err := transporter.NewReceivePackSession(endpoint, g.auth(remote))
+	if err != nil {
+		return false, fmt.Errorf("failed to reach remote %s: %w", name, withAuthHint(err))
+	}
+	defer session.Close()
+
+	refs, err := session.AdvertisedReferences()
+	if err != nil {
+		return false, fmt.Errorf("failed to reach remote %s: %w", name, err)
+	}
+	return refs.Capabilities.Supports(capability.Atomic), nil
+}
+
+func (g *GoGitBackend) Status() (string, error) {
+	wt, err := g.worktree()
+	if err != nil {
+		return "", err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return "", err
+	}
+	return status.String(), nil
+}
+
+func (g *GoGitBackend) IsClean() (bool, error) {
+	wt, err := g.worktree()
+	if err != nil {
+		return false, err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return false, err
+	}
+	return status.IsClean(), nil
+}
+
+// CurrentBranch returns the checked out branch, or "HEAD" when it is detached.
+func (g *GoGitBackend) CurrentBranch() (string, error) {
+	repo, err := g.open()
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	if !head.Name().IsBranch() {
+		return "HEAD", nil
+	}
+	return head.Name().Short(), nil
+}
+
+func (g *GoGitBackend) IsRepository() bool {
+	_, err := g.open()
+	return err == nil
+}
+
+func (g *GoGitBackend) TagExists(tagName string) bool {
+	repo, err := g.open()
+	if err != nil {
+		return false
+	}
+	_, err = repo.ResolveRevision(plumbing.Revision(tagName))
+	return err == nil
+}
+
+// LocalTag returns the object a local tag points at, which is the tag object
+// for annotated tags, and the commit it tags. ok is false when there is no
+// such tag.
+func (g *GoGitBackend) LocalTag(tagName string) (object, commit string, ok bool) {
+	repo, err := g.open()
+	if err != nil {
+		return "", "", false
+	}
+	ref, err := repo.Reference(plumbing.NewTagReferenceName(tagName), true)
+	if err != nil {
+		return "", "", false
+	}
+
+	hash, err := g.peel(ref.Hash())
+	if err != nil {
+		return ref.Hash().String(), "", true
+	}
+	return ref.Hash().String(), hash.String(), true
+}
+
+// peel returns the commit hash points at, through annotated tags.
+func (g *GoGitBackend) peel(hash plumbing.Hash) (plumbing.Hash, error) {
+	repo, err := g.open()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	for {
+		tag, err := repo.TagObject(hash)
+		if err != nil {
+			break
+		}
+		hash = tag.Target
+	}
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return commit.Hash, nil
+}
+
+// RemoteTag returns the object a tag on remote points at and the commit it
+// tags, like LocalTag. ok is false when remote has no such tag.
+func (g *GoGitBackend) RemoteTag(name, tagName string) (object, commit string, ok bool, err error) {
+	refs, err := g.list(name)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	var peeled string
+	ref := "refs/tags/" + tagName
+	for _, r := range refs {
+		switch r.Name().String() {
+		case ref + "^{}":
+			// The peeled commit of an annotated tag
+			peeled = r.Hash().String()
+		case ref:
+			object, ok = r.Hash().String(), true
+		}
+	}
+
+	commit = object
+	if peeled != "" {
+		commit = peeled
+	}
+	return object, commit, ok, nil
+}
+
+func (g *GoGitBackend) list(name string) ([]*plumbing.Reference, error) {
+	g.log("ls-remote %s", name)
+	remote, err := g.remote(name)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := remote.List(&git.ListOptions{PeelingOption: git.AppendPeeled, Auth: g.auth(remote)})
+	if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return nil, nil
+	}
+	return refs, withAuthHint(err)
+}
+
+// RestoreTag points tagName at object again, e.g. after it was deleted.
+func (g *GoGitBackend) RestoreTag(tagName, object string) error {
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+	return repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewTagReferenceName(tagName), plumbing.NewHash(object)))
+}
+
+// HasRemote reports whether remote is configured.
+func (g *GoGitBackend) HasRemote(remote string) bool {
+	repo, err := g.open()
+	if err != nil {
+		return false
+	}
+	_, err = repo.Remote(remote)
+	return err == nil
+}
+
+// Divergence fetches branch from remote and counts the commits only HEAD has
+// and the ones only the remote branch has. found is false when remote has no
+// such branch.
+func (g *GoGitBackend) Divergence(name, branch string) (ahead, behind int, found bool, err error) {
+	repo, err := g.open()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	refs, err := g.list(name)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("failed to reach remote %s: %w", name, err)
+	}
+
+	var remoteHead plumbing.Hash
+	for _, ref := range refs {
+		if ref.Name() == plumbing.NewBranchReferenceName(branch) {
+			remoteHead, found = ref.Hash(), true
+		}
+	}
+	if !found {
+		return 0, 0, false, nil
+	}
+
+	if _, err := repo.CommitObject(remoteHead); err != nil {
+		g.log("fetch %s refs/heads/%s", name, branch)
+		remote, err := g.remote(name)
+		if err != nil {
+			return 0, 0, true, err
+		}
+		refspec := config.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", branch, name, branch))
+		err = remote.Fetch(&git.FetchOptions{RemoteName: name, RefSpecs: []config.RefSpec{refspec}, Auth: g.auth(remote)})
+		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return 0, 0, true, fmt.Errorf("failed to fetch %s from %s: %w", branch, name, withAuthHint(err))
+		}
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return 0, 0, true, err
+	}
+	local, err := g.reachable(head.Hash())
+	if err != nil {
+		return 0, 0, true, fmt.Errorf("failed to compare with %s/%s: %w", name, branch, err)
+	}
+	upstream, err := g.reachable(remoteHead)
+	if err != nil {
+		return 0, 0, true, fmt.Errorf("failed to compare with %s/%s: %w", name, branch, err)
+	}
+
+	for hash := range local {
+		if !upstream[hash] {
+			ahead++
+		}
+	}
+	for hash := range upstream {
+		if !local[hash] {
+			behind++
+		}
+	}
+	return ahead, behind, true, nil
+}
+
+// reachable returns the commits reachable from hash.
+func (g *GoGitBackend) reachable(hash plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	repo, err := g.open()
+	if err != nil {
+		return nil, err
+	}
+	commits, err := repo.Log(&git.LogOptions{From: hash})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[plumbing.Hash]bool{}
+	err = commits.ForEach(func(commit *object.Commit) error {
+		seen[commit.Hash] = true
+		return nil
+	})
+	return seen, err
+}
+
+// Upstream returns the remote and remote branch that branch tracks, empty
+// when it tracks none.
+func (g *GoGitBackend) Upstream(branch string) (remote, remoteBranch string) {
+	repo, err := g.open()
+	if err != nil {
+		return "", ""
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return "", ""
+	}
+	if tracking, ok := cfg.Branches[branch]; ok {
+		return tracking.Remote, strings.TrimPrefix(tracking.Merge.String(), "refs/heads/")
+	}
+	return "", ""
+}
+
+// Head returns the commit HEAD points at.
+func (g *GoGitBackend) Head() (string, error) {
+	repo, err := g.open()
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	return head.Hash().String(), nil
+}
+
+// Reset moves the current branch back to commit, keeping the working tree.
+func (g *GoGitBackend) Reset(commit string) error {
+	g.log("reset %s", commit)
+	wt, err := g.worktree()
+	if err != nil {
+		return err
+	}
+	return wt.Reset(&git.ResetOptions{Commit: plumbing.NewHash(commit), Mode: git.MixedReset})
+}
+
+// GitDir returns the absolute path of the repository's .git directory.
+func (g *GoGitBackend) GitDir() (string, error) {
+	repo, err := g.open()
+	if err != nil {
+		return "", err
+	}
+	storage, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return "", fmt.Errorf("repository is not stored on disk")
+	}
+	return filepath.Abs(storage.Filesystem().Root())
+}
+
+// RestoreFiles checks out files as they were at commit, in the index and the
+// working tree.
+func (g *GoGitBackend) RestoreFiles(commit string, files ...string) error {
+	g.log("checkout %s -- %s", commit, strings.Join(files, " "))
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	c, err := repo.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		rel, err := g.relativePath(wt, file)
+		if err != nil {
+			return err
+		}
+		blob, err := c.File(rel)
+		if err != nil {
+			return fmt.Errorf("failed to find %s in %s: %w", rel, commit, err)
+		}
+		content, err := blob.Contents()
+		if err != nil {
+			return err
+		}
+		mode, err := blob.Mode.ToOSFileMode()
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(filepath.Join(wt.Filesystem.Root(), rel), []byte(content), mode.Perm()); err != nil {
+			return err
+		}
+		if _, err := wt.Add(rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MergedTags lists the tags starting with prefix that are reachable from
+// HEAD, sorted by name.
+func (g *GoGitBackend) MergedTags(prefix string) ([]string, error) {
+	repo, err := g.open()
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	merged, err := g.reachable(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		if commit, err := g.peel(ref.Hash()); err == nil && merged[commit] {
+			tags = append(tags, name)
+		}
+		return nil
+	})
+	sort.Strings(tags)
+	return tags, err
+}
+
+// CommitSubjects lists the subjects of the commits reachable from HEAD but not
+// from since, newest first. An empty since lists the whole history.
+func (g *GoGitBackend) CommitSubjects(since string) ([]string, error) {
+	repo, err := g.open()
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := map[plumbing.Hash]bool{}
+	if since != "" {
+		hash, err := repo.ResolveRevision(plumbing.Revision(since))
+		if err != nil {
+			return nil, err
+		}
+		if excluded, err = g.reachable(*hash); err != nil {
+			return nil, err
+		}
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	commits, err := repo.Log(&git.LogOptions{From: head.Hash(), Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, err
+	}
+
+	var subjects []string
+	err = commits.ForEach(func(commit *object.Commit) error {
+		if excluded[commit.Hash] || commit.NumParents() > 1 {
+			return nil
+		}
+		// Like %s, the subject is the first paragraph on one line
+		paragraph, _, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n\n")
+		if subject := strings.Join(strings.Fields(paragraph), " "); subject != "" {
+			subjects = append(subjects, subject)
+		}
+		return nil
+	})
+	return subjects, err
+}
+
+// signature returns the author or committer identity git would use: the
+// GIT_<role>_NAME and GIT_<role>_EMAIL variables, or user.name and user.email.
+func (g *GoGitBackend) signature(role string) (*object.Signature, error) {
+	repo, err := g.open()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := repo.ConfigScoped(config.SystemScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git config: %w", err)
+	}
+
+	name, email := cfg.User.Name, cfg.User.Email
+	if value := os.Getenv("GIT_" + role + "_NAME"); value != "" {
+		name = value
+	}
+	if value := os.Getenv("GIT_" + role + "_EMAIL"); value != "" {
+		email = value
+	}
+	if name == "" || email == "" {
+		return nil, fmt.Errorf("user.name and user.email must be set in the git config")
+	}
+	return &object.Signature{Name: name, Email: email, When: time.Now()}, nil
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -12,6 +14,16 @@ import (
 type CommandRunner interface {
 	Run(ctx context.Context, cmd string, args ...string) (*CommandResult, error)
 	RunWithOutput(ctx context.Context, cmd string, args ...string) (*CommandResult, error)
+	// RunWithInput feeds input to the command's stdin and captures its output
+	RunWithInput(ctx context.Context, input string, cmd string, args ...string) (*CommandResult, error)
+}
+
+// EnvRunner is implemented by runners that can add environment variables to a
+// command, e.g. to get output in a known language.
+type EnvRunner interface {
+	// RunWithEnv captures the output of the command run with env added to the
+	// environment
+	RunWithEnv(ctx context.Context, env []string, cmd string, args ...string) (*CommandResult, error)
 }
 
 type CommandResult struct {
@@ -25,27 +37,46 @@ type CommandResult struct {
 
 type DefaultRunner struct {
 	verbose bool
+	dir     string
 }
 
 func NewRunner(verbose bool) CommandRunner {
 	return &DefaultRunner{verbose: verbose}
 }
 
+// NewRunnerInDir returns a runner that executes every command in dir.
+func NewRunnerInDir(dir string, verbose bool) CommandRunner {
+	return &DefaultRunner{verbose: verbose, dir: dir}
+}
+
 func (r *DefaultRunner) Run(ctx context.Context, cmd string, args ...string) (*CommandResult, error) {
-	return r.execute(ctx, false, cmd, args...)
+	return r.execute(ctx, false, nil, nil, cmd, args...)
 }
 
 func (r *DefaultRunner) RunWithOutput(ctx context.Context, cmd string, args ...string) (*CommandResult, error) {
-	return r.execute(ctx, true, cmd, args...)
+	return r.execute(ctx, true, nil, nil, cmd, args...)
 }
 
-func (r *DefaultRunner) execute(ctx context.Context, captureOutput bool, cmd string, args ...string) (*CommandResult, error) {
+func (r *DefaultRunner) RunWithInput(ctx context.Context, input string, cmd string, args ...string) (*CommandResult, error) {
+	return r.execute(ctx, true, nil, strings.NewReader(input), cmd, args...)
+}
+
+func (r *DefaultRunner) RunWithEnv(ctx context.Context, env []string, cmd string, args ...string) (*CommandResult, error) {
+	return r.execute(ctx, true, env, nil, cmd, args...)
+}
+
+func (r *DefaultRunner) execute(ctx context.Context, captureOutput bool, env []string, stdin io.Reader, cmd string, args ...string) (*CommandResult, error) {
 	if r.verbose {
 		fmt.Printf("→ %s %s\n", cmd, strings.Join(args, " "))
 	}
 
 	start := time.Now()
 	command := exec.CommandContext(ctx, cmd, args...)
+	command.Dir = r.dir
+	command.Stdin = stdin
+	if len(env) > 0 {
+		command.Env = append(os.Environ(), env...)
+	}
 	
 	var stdout, stderr bytes.Buffer
 	if captureOutput {
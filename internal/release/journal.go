@@ -0,0 +1,129 @@
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// journal records an in-progress release under .git/bumpr/ so that an
+// interrupted release can be resumed or aborted instead of bumped again. The
+// steps of the plan run in order, so the completed ones are a prefix.
+type journal struct {
+	path string
+
+	Plan
+	ReleaseCommit string    `json:"release_commit,omitempty"`
+	Completed     int       `json:"completed"`
+	Published     int       `json:"published"`
+	StartedAt     time.Time `json:"started_at"`
+}
+
+func journalPath(gitDir string) string {
+	return filepath.Join(gitDir, "bumpr", "release.json")
+}
+
+// loadJournal reads the journal at path, returning nil when there is none.
+func loadJournal(path string) (*journal, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read release journal: %w", err)
+	}
+
+	j := &journal{path: path}
+	if err := json.Unmarshal(content, j); err != nil {
+		return nil, fmt.Errorf("failed to parse release journal %s: %w", path, err)
+	}
+	return j, nil
+}
+
+func (j *journal) save() error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	content, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode release journal: %w", err)
+	}
+
+	// Write and rename so an interruption never leaves a truncated journal
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write release journal: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return fmt.Errorf("failed to write release journal: %w", err)
+	}
+	return nil
+}
+
+func (j *journal) remove() error {
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove release journal: %w", err)
+	}
+	return nil
+}
+
+// done reports whether a step of the given kind has completed.
+func (j *journal) done(kind StepKind) bool {
+	for _, step := range j.Steps[:min(j.Completed, len(j.Steps))] {
+		if step.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *journal) completedKinds() []string {
+	var kinds []string
+	for _, step := range j.Steps[:min(j.Completed, len(j.Steps))] {
+		kinds = append(kinds, string(step.Kind))
+	}
+	return kinds
+}
+
+// tagPushed reports whether a completed step pushed the tag, alone or
+// atomically with the release commit.
+func (j *journal) tagPushed() bool {
+	push := j.step(StepPush)
+	return j.done(StepPushTag) || (push != nil && push.Tag != "" && j.done(StepPush))
+}
+
+// tagPending reports whether the tag step is the next one to run.
+func (j *journal) tagPending() bool {
+	return j.Completed < len(j.Steps) && j.Steps[j.Completed].Kind == StepTag
+}
+
+// updatedFiles lists the files changed by the completed steps.
+func (j *journal) updatedFiles() []string {
+	completed := Plan{Steps: j.Steps[:min(j.Completed, len(j.Steps))]}
+	return completed.files()
+}
+
+func (j *journal) complete() error {
+	j.Completed++
+	return j.save()
+}
+
+// publish marks the completed steps as visible on the remote; a rollback
+// keeps them.
+func (j *journal) publish() error {
+	j.Published = j.Completed
+	return j.save()
+}
+
+// rolledBack forgets the steps undone by a rollback, i.e. all but the first
+// kept ones. The journal is removed when nothing of the release is left.
+func (j *journal) rolledBack(kept int) error {
+	j.Completed = min(kept, j.Completed)
+	if j.Completed == 0 {
+		return j.remove()
+	}
+	return j.save()
+}
@@ -0,0 +1,92 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testPlan() Plan {
+	return Plan{
+		Version: "1.2.4",
+		Tag:     "v1.2.4",
+		Steps: []Step{
+			{Kind: StepUpdateFile, Files: []string{".version"}, Version: "1.2.4"},
+			{Kind: StepCommit, Files: []string{".version"}, Message: "releasing 1.2.4"},
+			{Kind: StepPush, Remote: "origin", Branch: "main", Target: "main"},
+			{Kind: StepTag, Tag: "v1.2.4", Message: "Release: 1.2.4"},
+			{Kind: StepPushTag, Remote: "origin", Tag: "v1.2.4"},
+		},
+	}
+}
+
+func TestJournal_SaveAndLoad(t *testing.T) {
+	path := journalPath(filepath.Join(t.TempDir(), ".git"))
+
+	j, err := loadJournal(path)
+	if err != nil || j != nil {
+		t.Fatalf("loadJournal() without journal = %v, %v, want nil", j, err)
+	}
+
+	j = &journal{path: path, Plan: testPlan()}
+	if err := j.complete(); err != nil {
+		t.Fatalf("complete() error = %v", err)
+	}
+
+	loaded, err := loadJournal(path)
+	if err != nil {
+		t.Fatalf("loadJournal() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Plan, j.Plan) || loaded.Completed != 1 {
+		t.Errorf("loadJournal() = %+v, want %+v", loaded, j)
+	}
+	if !loaded.done(StepUpdateFile) || loaded.done(StepCommit) {
+		t.Errorf("done() does not match completed = %d", loaded.Completed)
+	}
+	if files := loaded.updatedFiles(); !reflect.DeepEqual(files, []string{".version"}) {
+		t.Errorf("updatedFiles() = %v", files)
+	}
+
+	if err := loaded.remove(); err != nil {
+		t.Fatalf("remove() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("remove() left the journal behind")
+	}
+}
+
+func TestJournal_RolledBack(t *testing.T) {
+	path := journalPath(t.TempDir())
+
+	j := &journal{path: path, Plan: testPlan()}
+	for i := 0; i < 3; i++ {
+		if err := j.complete(); err != nil {
+			t.Fatalf("complete() error = %v", err)
+		}
+	}
+	if err := j.publish(); err != nil {
+		t.Fatalf("publish() error = %v", err)
+	}
+	j.complete()
+
+	// A rollback undoes the tag but not the pushed commit
+	if err := j.rolledBack(j.Published); err != nil {
+		t.Fatalf("rolledBack() error = %v", err)
+	}
+	loaded, _ := loadJournal(path)
+	if loaded == nil || loaded.Completed != 3 || loaded.done(StepTag) {
+		t.Errorf("after rollback journal = %+v, want the pushed steps only", loaded)
+	}
+	if !loaded.tagPending() {
+		t.Error("tagPending() = false with the tag step next")
+	}
+
+	// Nothing published: the release is gone entirely
+	if err := loaded.rolledBack(0); err != nil {
+		t.Fatalf("rolledBack() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("rolledBack() should remove an empty journal")
+	}
+}
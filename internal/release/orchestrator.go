@@ -1,90 +1,440 @@
 package release
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/oriol/bumpr/internal/config"
 	"github.com/oriol/bumpr/internal/external"
 	"github.com/oriol/bumpr/internal/sources"
 	"github.com/oriol/bumpr/internal/version"
 )
 
 type Options struct {
-	BumpType string
-	Source   string
-	DryRun   bool
-	Verbose  bool
-	NoPush   bool
-	NoCommit bool
-	Quiet    bool
-	Force    bool
+	BumpType       string
+	Source         string
+	SourcePattern  string
+	DryRun         bool
+	Verbose        bool
+	NoPush         bool
+	NoCommit       bool
+	Quiet          bool
+	Force          bool
+	NoRollback     bool
+	Confirm        bool
+	ReplaceTag     bool
+	AllowAnyBranch bool
+	AtomicPush     bool
+	Sign           bool
+	SigningKey     string
+	Remote         string
+	UpstreamBranch string
+	TagPrefix      string
 }
 
 type Orchestrator struct {
-	detector   *sources.Detector
-	gitCmd     *external.GitCommands
-	githubCmd  *external.GitHubCommands
-	checker    *external.DependencyChecker
+	detector    *sources.Detector
+	gitCmd      external.GitBackend
+	gitBackend  string
+	githubCmd   *external.GitHubCommands
+	checker     *external.DependencyChecker
+	images      []*sources.ImageUpdater
+	branchRules []config.BranchRule
+	prompter    *Prompter
+	root        string
 }
 
-func NewOrchestrator(runner external.CommandRunner, verbose bool) *Orchestrator {
-	return &Orchestrator{
-		detector:  sources.NewDetector(),
-		gitCmd:    external.NewGitCommands(runner, verbose),
-		githubCmd: external.NewGitHubCommands(runner, verbose),
-		checker:   external.NewDependencyChecker(runner),
+// NewOrchestrator creates an orchestrator for the project at root. Sources are
+// detected relative to root and runner is expected to execute commands there.
+func NewOrchestrator(runner external.CommandRunner, cfg *config.Config, root string, verbose bool) (*Orchestrator, error) {
+	git, err := external.NewGitBackend(cfg.GitBackend, runner, root, verbose)
+	if err != nil {
+		return nil, err
 	}
+
+	detector := sources.NewDetector()
+	configured := map[string]bool{}
+	for _, sc := range cfg.Sources {
+		source, err := sources.NewFromConfig(sc, runner, git, cfg.TagPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source in config: %w", err)
+		}
+		detector.Register(source)
+		configured[source.Name()] = true
+	}
+	// Plugins on PATH are tried after the built-in sources
+	for _, plugin := range sources.DiscoverPlugins(runner) {
+		if !configured[plugin.Name()] {
+			detector.Append(plugin)
+		}
+	}
+	// Repositories without any version file fall back to their tags
+	detector.RegisterFallback(sources.NewGitTagSource(git, cfg.TagPrefix))
+	detector.SetPriority(cfg.Priority)
+	detector.SetGit(git)
+
+	var images []*sources.ImageUpdater
+	for _, ic := range cfg.Images {
+		images = append(images, sources.NewImageUpdater(ic))
+	}
+
+	return &Orchestrator{
+		detector:    detector,
+		gitCmd:      git,
+		gitBackend:  cfg.GitBackend,
+		githubCmd:   external.NewGitHubCommands(runner, verbose),
+		checker:     external.NewDependencyChecker(runner, git),
+		images:      images,
+		branchRules: cfg.Branches,
+		prompter:    NewPrompter(os.Stdin, os.Stdout),
+		root:        root,
+	}, nil
 }
 
+// Execute runs a release. When a step fails, the steps completed before it
+// are undone in reverse order unless options.NoRollback is set. Progress is
+// recorded in a journal so an interrupted release can be resumed or aborted.
 func (o *Orchestrator) Execute(options Options) error {
+	if !options.DryRun {
+		if err := o.checkNoInterruptedRelease(); err != nil {
+			return err
+		}
+	}
+
 	if !options.Quiet {
 		fmt.Printf("🚀 Starting release process...\n\n")
 	}
 
-	// Pre-flight checks
+	plan, err := o.Plan(options)
+	if err != nil {
+		return err
+	}
+
+	if options.DryRun {
+		fmt.Println("🔍 Dry run mode - commands that would be executed:")
+		fmt.Println()
+		plan.Print()
+		fmt.Println()
+		fmt.Println("Run without --dry-run to execute these commands.")
+		return nil
+	}
+
+	if err := o.confirm(plan, options); err != nil {
+		return err
+	}
+
+	return o.start(plan, options)
+}
+
+// Apply executes a plan saved by `bumpr plan`. It refuses a plan made for
+// another commit than the current HEAD.
+func (o *Orchestrator) Apply(plan *Plan, options Options) error {
+	if err := o.checkNoInterruptedRelease(); err != nil {
+		return err
+	}
+
 	if !options.Force {
+		options.NoCommit = plan.NoCommit
 		if err := o.runPreflightChecks(options); err != nil {
 			return fmt.Errorf("pre-flight check failed: %w", err)
 		}
+	} else if _, err := o.checker.CheckBranch(); err != nil {
+		// --force skips the checks, but never releases from a detached HEAD
+		return fmt.Errorf("pre-flight check failed: %w", err)
+	}
+	if err := o.checkBranchPolicy(options); err != nil {
+		return fmt.Errorf("pre-flight check failed: %w", err)
+	}
+	if key, sign := plan.signingKey(); sign && !options.Force {
+		if err := o.checker.CheckSigningKey(key); err != nil {
+			return fmt.Errorf("pre-flight check failed: %w", err)
+		}
 	}
 
-	// Detect or use specified version source
-	source, sourceFile, err := o.detectVersionSource(options.Source)
+	head, err := o.gitCmd.Head()
+	if err != nil {
+		return fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	if head != plan.PreviousHead {
+		return fmt.Errorf("the plan was made at commit %s but HEAD is %s, create a new plan", shortCommit(plan.PreviousHead), shortCommit(head))
+	}
+	// The configured backend runs the plan, whichever one it was made with
+	plan.GitBackend = o.gitBackend
+
+	if options.DryRun {
+		fmt.Println("🔍 Dry run mode - commands that would be executed:")
+		fmt.Println()
+		plan.Print()
+		return nil
+	}
+
+	if err := o.confirm(plan, options); err != nil {
+		return err
+	}
+
+	if !options.Quiet {
+		fmt.Printf("📋 Applying plan: release %s\n\n", plan.Version)
+	}
+
+	return o.start(plan, options)
+}
+
+// confirm shows what the release will do and asks to go ahead when
+// options.Confirm is set.
+func (o *Orchestrator) confirm(plan *Plan, options Options) error {
+	if !options.Confirm {
+		return nil
+	}
+
+	o.showSummary(plan)
+
+	ok, err := o.prompter.Confirm("Proceed with the release?")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("release cancelled")
+	}
+	fmt.Println()
+	return nil
+}
+
+func (o *Orchestrator) showSummary(plan *Plan) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "📋 Release summary:")
+
+	if plan.BumpType == "republish" {
+		fmt.Fprintf(w, "   Version:\t%s (republish)\n", plan.Version)
+	} else {
+		fmt.Fprintf(w, "   Version:\t%s → %s\n", plan.PreviousVersion, plan.Version)
+	}
+
+	files := plan.files()
+	if len(files) == 0 {
+		files = []string{"none"}
+	}
+	fmt.Fprintf(w, "   Files:\t%s\n", strings.Join(files, ", "))
+
+	if commit := plan.step(StepCommit); commit != nil {
+		fmt.Fprintf(w, "   Commit:\t%s\n", commit.Message)
+	} else {
+		fmt.Fprintf(w, "   Commit:\tnone\n")
+	}
+
+	fmt.Fprintf(w, "   Tag:\t%s\n", plan.Tag)
+
+	if key, sign := plan.signingKey(); sign && key != "" {
+		fmt.Fprintf(w, "   Signed:\twith %s\n", key)
+	} else if sign {
+		fmt.Fprintf(w, "   Signed:\twith user.signingkey\n")
+	}
+
+	if plan.NoPush {
+		fmt.Fprintf(w, "   Remote:\tnone (--no-push)\n")
+	} else {
+		fmt.Fprintf(w, "   Remote:\t%s\n", plan.Remote)
+	}
+
+	if push := plan.step(StepPush); push != nil && push.Tag != "" {
+		fmt.Fprintf(w, "   Branch:\t%s → %s/%s, atomically with the tag\n", push.Branch, push.Remote, push.Target)
+	} else if push != nil {
+		fmt.Fprintf(w, "   Branch:\t%s → %s/%s\n", push.Branch, push.Remote, push.Target)
+	} else if branch, err := o.gitCmd.CurrentBranch(); err == nil {
+		fmt.Fprintf(w, "   Branch:\t%s (not pushed)\n", branch)
+	}
+
+	w.Flush()
+	fmt.Println()
+}
+
+// ChooseBumpType asks for the kind of release, showing the version each one
+// would produce.
+func (o *Orchestrator) ChooseBumpType(options Options) (string, error) {
+	source, sourceFile, err := o.detectVersionSource(options.Source, options.SourcePattern)
+	if err != nil {
+		return "", err
+	}
+
+	currentVersion, err := source.GetVersion(sourceFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current version: %w", err)
+	}
+
+	bumpTypes := []string{"patch", "minor", "major", "republish"}
+	var choices []string
+	for _, bumpType := range bumpTypes {
+		if bumpType == "republish" {
+			choices = append(choices, fmt.Sprintf("%-10s %s", bumpType, currentVersion))
+			continue
+		}
+
+		parsed, _ := version.ParseBumpType(bumpType)
+		newVersion, err := version.Bump(currentVersion, parsed)
+		if err != nil {
+			return "", fmt.Errorf("failed to bump version: %w", err)
+		}
+		choices = append(choices, fmt.Sprintf("%-10s %s → %s", bumpType, currentVersion, newVersion))
+	}
+
+	fmt.Printf("📄 Using version source: %s\n\n", o.displayPath(sourceFile))
+
+	choice, err := o.prompter.Choose("What kind of release?", choices)
 	if err != nil {
+		return "", err
+	}
+	fmt.Println()
+
+	return bumpTypes[choice], nil
+}
+
+func (o *Orchestrator) checkNoInterruptedRelease() error {
+	j, err := o.loadJournal()
+	if err != nil {
+		return err
+	}
+	if j != nil {
+		return fmt.Errorf("the release of %s was interrupted. Run 'bumpr resume' to finish it or 'bumpr abort' to discard it", j.Version)
+	}
+	return nil
+}
+
+// start records the plan in a new journal and runs it.
+func (o *Orchestrator) start(plan *Plan, options Options) error {
+	gitDir, err := o.gitCmd.GitDir()
+	if err != nil {
+		return fmt.Errorf("failed to locate git directory: %w", err)
+	}
+
+	j := &journal{
+		path:      journalPath(gitDir),
+		Plan:      *plan,
+		StartedAt: time.Now().UTC(),
+	}
+	if err := j.save(); err != nil {
+		return err
+	}
+
+	return o.run(j, options)
+}
+
+// run executes the steps not yet completed according to the journal.
+func (o *Orchestrator) run(j *journal, options Options) error {
+	rb := &rollback{}
+	completedBefore := j.Completed
+
+	err := o.runSteps(j, options, rb)
+	if err == nil {
+		if err := j.remove(); err != nil {
+			return err
+		}
+		o.showSuccessMessage(&j.Plan, options)
+		return nil
+	}
+
+	if options.NoRollback {
+		if pending := rb.pending(); len(pending) > 0 && !options.Quiet {
+			fmt.Println()
+			fmt.Println("⚠️  Rollback skipped (--no-rollback). Left to undo by hand:")
+			for _, description := range pending {
+				fmt.Printf("   - %s\n", description)
+			}
+		}
+		return withJournalHint(err, j)
+	}
+
+	if rollbackErr := rb.run(options.Quiet); rollbackErr != nil {
+		return withJournalHint(fmt.Errorf("%w\nrollback incomplete: %v", err, rollbackErr), j)
+	}
+
+	// Steps of earlier runs and published steps were not rolled back
+	if err := j.rolledBack(max(completedBefore, j.Published)); err != nil {
 		return err
 	}
+	if j.Completed > 0 {
+		return withJournalHint(err, j)
+	}
+	return err
+}
+
+func withJournalHint(err error, j *journal) error {
+	return fmt.Errorf("%w\nThe release of %s is incomplete. Run 'bumpr resume' to retry or 'bumpr abort' to discard it", err, j.Version)
+}
+
+// Plan runs the pre-flight checks and computes the release steps without
+// changing anything.
+func (o *Orchestrator) Plan(options Options) (*Plan, error) {
+	// Pre-flight checks
+	if !options.Force {
+		if err := o.runPreflightChecks(options); err != nil {
+			return nil, fmt.Errorf("pre-flight check failed: %w", err)
+		}
+	} else if _, err := o.checker.CheckBranch(); err != nil {
+		// --force skips the checks, but never releases from a detached HEAD
+		return nil, fmt.Errorf("pre-flight check failed: %w", err)
+	}
+	// Branch rules are policy, --force does not lift them
+	if err := o.checkBranchPolicy(options); err != nil {
+		return nil, fmt.Errorf("pre-flight check failed: %w", err)
+	}
+
+	if options.Sign && !options.Force {
+		if err := o.checker.CheckSigningKey(options.SigningKey); err != nil {
+			return nil, fmt.Errorf("pre-flight check failed: %w", err)
+		}
+	}
+
+	// Detect or use specified version source
+	source, sourceFile, err := o.detectVersionSource(options.Source, options.SourcePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tag-only sources have no file to update
+	tagOnly := false
+	if tagSource, ok := source.(sources.TagOnlySource); ok && tagSource.IsTagOnly(sourceFile) {
+		tagOnly = true
+	}
 
 	if !options.Quiet {
-		fmt.Printf("📄 Using version source: %s\n", sourceFile)
+		if tagOnly {
+			fmt.Printf("📄 Using version source: %s (%s)\n", source.Name(), o.displayPath(sourceFile))
+		} else {
+			fmt.Printf("📄 Using version source: %s\n", o.displayPath(sourceFile))
+		}
 	}
 
 	// Get current version
 	currentVersion, err := source.GetVersion(sourceFile)
 	if err != nil {
-		return fmt.Errorf("failed to get current version: %w", err)
+		return nil, fmt.Errorf("failed to get current version: %w", err)
 	}
 
 	// Calculate new version
+	republish := options.BumpType == "republish"
 	var newVersion string
-	if options.BumpType == "republish" {
+	if republish {
 		// For republish, use the current version
 		newVersion = currentVersion
 	} else {
 		// Parse bump type
 		bumpType, err := version.ParseBumpType(options.BumpType)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		
+
 		newVersion, err = version.Bump(currentVersion, bumpType)
 		if err != nil {
-			return fmt.Errorf("failed to bump version: %w", err)
+			return nil, fmt.Errorf("failed to bump version: %w", err)
 		}
 	}
 
 	if !options.Quiet {
-		if options.BumpType == "republish" {
+		if republish {
 			fmt.Printf("🔄 Republishing version: %s\n\n", currentVersion)
 		} else {
 			fmt.Printf("📊 Current version: %s\n", currentVersion)
@@ -92,111 +442,494 @@ func (o *Orchestrator) Execute(options Options) error {
 		}
 	}
 
-	if options.DryRun {
-		o.showDryRunCommands(sourceFile, newVersion, options)
-		return nil
+	if validator, ok := source.(sources.VersionValidator); ok {
+		if err := validator.ValidateVersion(newVersion); err != nil {
+			return nil, fmt.Errorf("invalid version for %s: %w", filepath.Base(sourceFile), err)
+		}
+	}
+
+	if advisor, ok := source.(sources.Advisor); ok && !options.Quiet {
+		for _, warning := range advisor.Advise(sourceFile, currentVersion, newVersion) {
+			fmt.Printf("⚠️  Warning: %s\n", warning)
+		}
+	}
+
+	previousHead, err := o.gitCmd.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD: %w", err)
+	}
+
+	remote, branch, target, err := o.resolveUpstream(options)
+	if err != nil {
+		return nil, err
+	}
+
+	tagName := options.TagPrefix + newVersion
+	if validator, ok := source.(sources.TagValidator); ok {
+		if err := validator.ValidateTag(sourceFile, tagName, newVersion); err != nil {
+			return nil, fmt.Errorf("invalid tag for %s: %w", filepath.Base(sourceFile), err)
+		}
+	}
+
+	plan := &Plan{
+		BumpType:        options.BumpType,
+		PreviousVersion: currentVersion,
+		Version:         newVersion,
+		Tag:             tagName,
+		PreviousTag:     options.TagPrefix + currentVersion,
+		SourceName:      source.Name(),
+		SourceFile:      o.relativePath(sourceFile),
+		SourcePattern:   options.SourcePattern,
+		NoCommit:        options.NoCommit,
+		NoPush:          options.NoPush,
+		Remote:          remote,
+		PreviousHead:    previousHead,
+		GitBackend:      o.gitBackend,
+	}
+	if options.Source != "" {
+		plan.SourceOption = plan.SourceFile
 	}
 
-	// Update version file (skip for republish)
-	if options.BumpType != "republish" {
-		if err := source.SetVersion(sourceFile, newVersion); err != nil {
-			return fmt.Errorf("failed to update version: %w", err)
+	// Update version files (skip for republish and tag-only sources)
+	var files []string
+	if !republish {
+		if !tagOnly {
+			plan.Steps = append(plan.Steps, Step{Kind: StepUpdateFile, Files: []string{plan.SourceFile}, Version: newVersion})
+			files = append(files, plan.SourceFile)
+		}
+
+		for _, image := range o.images {
+			imageFiles, err := image.Files(o.root)
+			if err != nil {
+				return nil, err
+			}
+
+			step := Step{Kind: StepUpdateImage, Image: image.Name(), Version: newVersion}
+			for _, file := range imageFiles {
+				step.Files = append(step.Files, o.relativePath(file))
+			}
+			plan.Steps = append(plan.Steps, step)
+			files = append(files, step.Files...)
+		}
+	}
+
+	// Git operations
+	if !options.NoCommit && len(files) > 0 {
+		plan.Steps = append(plan.Steps, Step{Kind: StepCommit, Files: files, Message: fmt.Sprintf("releasing %s", newVersion), Sign: options.Sign, Key: options.SigningKey})
+	}
+
+	// An atomic push sends the commit with the tag, once the tag exists
+	push := Step{Kind: StepPush, Remote: remote, Branch: branch, Target: target}
+	atomic := options.AtomicPush && plan.has(StepCommit)
+	if plan.has(StepCommit) && !options.NoPush && !atomic {
+		plan.Steps = append(plan.Steps, push)
+	}
+
+	// Tag operations
+	ghAvailable := !options.NoPush && o.githubCmd.IsAvailable()
+	if republish && ghAvailable {
+		plan.Steps = append(plan.Steps, Step{Kind: StepDeleteRelease, Tag: tagName})
+	}
+
+	// An existing tag is only replaced on request, republish replaces it by definition
+	checkRemote := ""
+	if !options.NoPush {
+		checkRemote = remote
+	}
+	existing, err := o.inspectTag(tagName, checkRemote)
+	if err != nil {
+		return nil, err
+	}
+	if existing.exists() {
+		if !republish && !options.ReplaceTag {
+			return nil, fmt.Errorf("%s. Pass --replace-tag to move it to the release commit, or delete it first", existing.describe(previousHead))
 		}
 
 		if !options.Quiet {
-			fmt.Printf("✅ Updated %s with new version\n", filepath.Base(sourceFile))
+			fmt.Printf("⚠️  Warning: %s; it will be replaced\n\n", existing.describe(previousHead))
+		}
+		if existing.local {
+			plan.Steps = append(plan.Steps, Step{Kind: StepDeleteTag, Tag: tagName, Object: existing.localObject})
+		}
+		if existing.remote {
+			plan.Steps = append(plan.Steps, Step{Kind: StepDeleteRemoteTag, Remote: remote, Tag: tagName, Object: existing.remoteObject})
 		}
 	}
 
-	// Git operations (skip commit for republish)
-	if !options.NoCommit && options.BumpType != "republish" {
-		if err := o.gitCmd.Add(sourceFile); err != nil {
-			return fmt.Errorf("failed to stage file: %w", err)
+	plan.Steps = append(plan.Steps, Step{Kind: StepTag, Tag: tagName, Message: fmt.Sprintf("Release: %s", newVersion), Sign: options.Sign, Key: options.SigningKey})
+
+	if !options.NoPush {
+		if atomic {
+			push.Tag = tagName
+			plan.Steps = append(plan.Steps, push)
+		} else {
+			plan.Steps = append(plan.Steps, Step{Kind: StepPushTag, Remote: remote, Tag: tagName})
 		}
 
-		commitMessage := fmt.Sprintf("releasing %s", newVersion)
-		if err := o.gitCmd.Commit(commitMessage); err != nil {
-			return fmt.Errorf("failed to commit: %w", err)
+		if ghAvailable {
+			plan.Steps = append(plan.Steps, Step{
+				Kind:  StepGitHubRelease,
+				Tag:   tagName,
+				Title: fmt.Sprintf("Release %s", newVersion),
+				Notes: fmt.Sprintf("## Release %s\n\nAutomated release created by bumpr.", newVersion),
+			})
+		} else if !options.Quiet {
+			fmt.Println("ℹ️  GitHub CLI (gh) not found. Skipping release creation.")
+			fmt.Println("   Install it with: https://cli.github.com/")
+			fmt.Println()
 		}
+	}
 
+	return plan, nil
+}
+
+// pushAtomic pushes the release commit and its tag together, so the remote
+// never gets one without the other. A remote that does not support atomic
+// pushes gets them one after the other.
+func (o *Orchestrator) pushAtomic(step Step, options Options, rb *rollback) error {
+	err := o.gitCmd.PushAtomic(step.Remote, step.Branch, step.Target, step.Tag)
+	if errors.Is(err, external.ErrAtomicUnsupported) {
 		if !options.Quiet {
-			fmt.Printf("💾 Committed: %s\n", commitMessage)
+			fmt.Printf("⚠️  Warning: %s does not support atomic pushes, pushing the commit and the tag separately\n", step.Remote)
+		}
+		if err := o.gitCmd.Push(step.Remote, step.Branch, step.Target); err != nil {
+			return fmt.Errorf("failed to push commit: %w", err)
 		}
+		rb.keep()
+		if err := o.gitCmd.PushTag(step.Remote, step.Tag); err != nil {
+			return fmt.Errorf("failed to push tag: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to push commit and tag: %w", err)
+	}
 
-		// Push commit to origin
-		if !options.NoPush {
-			branch, err := o.gitCmd.CurrentBranch()
-			if err != nil {
-				return fmt.Errorf("failed to get current branch: %w", err)
+	rb.keep()
+	rb.add("delete remote tag "+step.Tag, func() error {
+		return o.gitCmd.DeleteRemoteTag(step.Remote, step.Tag)
+	})
+
+	if !options.Quiet {
+		fmt.Printf("📤 Pushed commit to %s/%s with tag %s\n", step.Remote, step.Target, step.Tag)
+	}
+	return nil
+}
+
+// resolveUpstream picks the remote and remote branch a release pushes to.
+// Unless options.Force is set, a release that pushes requires HEAD to be a
+// branch that is not behind the remote one.
+func (o *Orchestrator) resolveUpstream(options Options) (remote, branch, target string, err error) {
+	branch, err = o.gitCmd.CurrentBranch()
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	trackedRemote, trackedBranch := o.gitCmd.Upstream(branch)
+	remote = options.Remote
+	if remote == "" {
+		remote = trackedRemote
+	}
+	if remote == "" {
+		remote = "origin"
+	}
+
+	target = options.UpstreamBranch
+	if target == "" && trackedRemote == remote {
+		target = trackedBranch
+	}
+	if target == "" {
+		target = branch
+	}
+
+	if !options.NoPush && !options.Force {
+		ahead, err := o.checker.CheckUpstream(remote, target)
+		if err != nil {
+			return "", "", "", fmt.Errorf("pre-flight check failed: %w", err)
+		}
+		if ahead > 0 && !options.Quiet {
+			fmt.Printf("ℹ️  %d commit(s) not yet on %s/%s will be pushed with the release\n\n", ahead, remote, target)
+		}
+	}
+
+	return remote, branch, target, nil
+}
+
+// runSteps performs the steps that the journal does not list as completed,
+// registering a compensating action for each one.
+func (o *Orchestrator) runSteps(j *journal, options Options, rb *rollback) error {
+	for j.Completed < len(j.Steps) {
+		step := j.Steps[j.Completed]
+		if err := o.runStep(j, step, options, rb); err != nil {
+			return err
+		}
+		if err := j.complete(); err != nil {
+			return err
+		}
+		if step.Kind == StepPush {
+			if err := j.publish(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (o *Orchestrator) runStep(j *journal, step Step, options Options, rb *rollback) error {
+	var files []string
+	for _, file := range step.Files {
+		files = append(files, o.absolutePath(file))
+	}
+
+	switch step.Kind {
+	case StepUpdateFile:
+		source, err := o.planSource(&j.Plan)
+		if err != nil {
+			return err
+		}
+		for i, file := range files {
+			if err := rb.restoreFile(file, step.Files[i]); err != nil {
+				return err
 			}
+		}
 
-			if err := o.gitCmd.Push(branch); err != nil {
-				return fmt.Errorf("failed to push commit: %w", err)
+		if changelog, ok := source.(sources.ChangelogSource); ok {
+			changes, err := o.releaseChanges(j.PreviousTag)
+			if err != nil {
+				return fmt.Errorf("failed to collect changes: %w", err)
 			}
+			changelog.SetChanges(changes)
+		}
 
+		for _, file := range files {
+			if err := source.SetVersion(file, step.Version); err != nil {
+				return fmt.Errorf("failed to update version: %w", err)
+			}
 			if !options.Quiet {
-				fmt.Printf("📤 Pushed commit to origin/%s\n", branch)
+				fmt.Printf("✅ Updated %s with new version\n", filepath.Base(file))
 			}
 		}
-	}
 
-	// Tag operations
-	if options.BumpType == "republish" {
-		// For republish, we need to be more aggressive with cleanup
-		if err := o.forceCleanupTagAndRelease(newVersion, options); err != nil {
-			if !options.Quiet {
-				fmt.Printf("⚠️  Warning: failed to cleanup existing tag/release: %v\n", err)
+	case StepUpdateImage:
+		image := o.image(step.Image)
+		if image == nil {
+			return fmt.Errorf("image %s is not configured", step.Image)
+		}
+		for i, file := range files {
+			if err := rb.restoreFile(file, step.Files[i]); err != nil {
+				return err
 			}
 		}
-	} else {
-		if err := o.cleanupExistingTag(newVersion, options); err != nil {
+
+		changed, err := image.UpdateFiles(files, step.Version)
+		if err != nil {
+			return fmt.Errorf("failed to update image %s: %w", image.Name(), err)
+		}
+
+		if !options.Quiet {
+			if len(changed) == 0 {
+				fmt.Printf("⚠️  Warning: no versioned reference to image %s found\n", image.Name())
+			}
+			for _, file := range changed {
+				fmt.Printf("✅ Updated image %s in %s\n", image.Name(), o.displayPath(file))
+			}
+		}
+
+	case StepCommit:
+		rb.add("unstage release files", func() error {
+			return o.gitCmd.Reset(j.PreviousHead)
+		})
+		// Commands run in the project root, like the plan shows them
+		if err := o.gitCmd.Add(step.Files...); err != nil {
+			return fmt.Errorf("failed to stage file: %w", err)
+		}
+
+		commit := o.gitCmd.Commit
+		if step.Sign {
+			commit = func(message string) error {
+				return o.gitCmd.CommitSigned(message, step.Key)
+			}
+		}
+		if err := commit(step.Message); err != nil {
+			return fmt.Errorf("failed to commit: %w", err)
+		}
+		rb.add("reset release commit", func() error {
+			return o.gitCmd.Reset(j.PreviousHead)
+		})
+
+		releaseCommit, err := o.gitCmd.Head()
+		if err != nil {
+			return fmt.Errorf("failed to read HEAD: %w", err)
+		}
+		j.ReleaseCommit = releaseCommit
+
+		if !options.Quiet {
+			fmt.Printf("💾 Committed: %s\n", step.Message)
+		}
+
+	case StepPush:
+		if step.Tag != "" {
+			return o.pushAtomic(step, options, rb)
+		}
+		if err := o.gitCmd.Push(step.Remote, step.Branch, step.Target); err != nil {
+			return fmt.Errorf("failed to push commit: %w", err)
+		}
+		// The release commit is public now, rewriting it would diverge from the remote
+		rb.keep()
+
+		if !options.Quiet {
+			fmt.Printf("📤 Pushed commit to %s/%s\n", step.Remote, step.Target)
+		}
+
+	case StepDeleteRelease:
+		if err := o.githubCmd.DeleteRelease(step.Tag); err != nil {
 			if !options.Quiet {
-				fmt.Printf("⚠️  Warning: failed to cleanup existing tag: %v\n", err)
+				fmt.Printf("⚠️  Warning: failed to delete GitHub release %s: %v\n", step.Tag, err)
 			}
+		} else if !options.Quiet {
+			fmt.Printf("🗑️  Deleted GitHub release %s\n", step.Tag)
 		}
-	}
 
-	tagMessage := fmt.Sprintf("Release: %s", newVersion)
-	if err := o.gitCmd.CreateTag(newVersion, tagMessage); err != nil {
-		return fmt.Errorf("failed to create tag: %w", err)
-	}
+	case StepDeleteTag:
+		if options.Verbose && !options.Quiet {
+			fmt.Printf("🧹 Cleaning up existing tag %s...\n", step.Tag)
+		}
+		if err := o.gitCmd.DeleteLocalTag(step.Tag); err != nil {
+			return fmt.Errorf("failed to delete tag %s: %w", step.Tag, err)
+		}
+		if step.Object != "" {
+			rb.add("restore tag "+step.Tag, func() error {
+				return o.gitCmd.RestoreTag(step.Tag, step.Object)
+			})
+		}
 
-	if !options.Quiet {
-		fmt.Printf("🏷️  Created tag: %s\n", newVersion)
-	}
+	case StepDeleteRemoteTag:
+		if step.Object != "" {
+			// Keep the objects of the tag around to push it back on failure
+			if err := o.gitCmd.FetchTag(step.Remote, step.Tag); err != nil {
+				return fmt.Errorf("failed to fetch tag %s from %s: %w", step.Tag, step.Remote, err)
+			}
+		}
+		if err := o.gitCmd.DeleteRemoteTag(step.Remote, step.Tag); err != nil {
+			return fmt.Errorf("failed to delete tag %s on %s: %w", step.Tag, step.Remote, err)
+		}
+		if step.Object != "" {
+			rb.add("restore remote tag "+step.Tag, func() error {
+				return o.gitCmd.RestoreRemoteTag(step.Remote, step.Tag, step.Object)
+			})
+		}
+		if options.Verbose && !options.Quiet {
+			fmt.Printf("🧹 Deleted tag %s on %s\n", step.Tag, step.Remote)
+		}
 
-	if !options.NoPush {
-		// Force push the tag to ensure it's updated if it already existed
-		if err := o.gitCmd.PushTagWithForce(newVersion); err != nil {
+	case StepTag:
+		createTag := o.gitCmd.CreateTag
+		if step.Sign {
+			createTag = func(tagName, message string) error {
+				return o.gitCmd.CreateSignedTag(tagName, message, step.Key)
+			}
+		}
+		if err := createTag(step.Tag, step.Message); err != nil {
+			return fmt.Errorf("failed to create tag: %w", err)
+		}
+		rb.add("delete tag "+step.Tag, func() error {
+			return o.gitCmd.DeleteLocalTag(step.Tag)
+		})
+
+		if step.Sign {
+			if err := o.gitCmd.VerifyTag(step.Tag); err != nil {
+				return fmt.Errorf("failed to verify the signature of tag %s: %w", step.Tag, err)
+			}
+		}
+
+		if !options.Quiet {
+			if step.Sign {
+				fmt.Printf("🏷️  Created signed tag: %s\n", step.Tag)
+			} else {
+				fmt.Printf("🏷️  Created tag: %s\n", step.Tag)
+			}
+		}
+
+	case StepPushTag:
+		// Never forced: a tag that appeared on the remote meanwhile is a conflict
+		if err := o.gitCmd.PushTag(step.Remote, step.Tag); err != nil {
 			return fmt.Errorf("failed to push tag: %w", err)
 		}
+		rb.add("delete remote tag "+step.Tag, func() error {
+			return o.gitCmd.DeleteRemoteTag(step.Remote, step.Tag)
+		})
 
 		if !options.Quiet {
-			fmt.Printf("📤 Pushed tag: %s (forced)\n", newVersion)
-		}
-
-		// Create GitHub release
-		if o.githubCmd.IsAvailable() {
-			if err := o.createGitHubRelease(newVersion, options); err != nil {
-				if !options.Quiet {
-					fmt.Printf("⚠️  Warning: failed to create GitHub release: %v\n", err)
-					fmt.Println("   The tag has been pushed, so the workflow will still run.")
-				}
-			} else if !options.Quiet {
-				fmt.Printf("🎉 Created GitHub release for %s\n", newVersion)
+			fmt.Printf("📤 Pushed tag: %s\n", step.Tag)
+		}
+
+	case StepGitHubRelease:
+		if err := o.githubCmd.CreateRelease(step.Tag, step.Title, step.Notes); err != nil {
+			if !options.Quiet {
+				fmt.Printf("⚠️  Warning: failed to create GitHub release: %v\n", err)
+				fmt.Println("   The tag has been pushed, so the workflow will still run.")
 			}
-		} else if !options.Quiet {
-			fmt.Println("ℹ️  GitHub CLI (gh) not found. Skipping release creation.")
-			fmt.Println("   Install it with: https://cli.github.com/")
+			return nil
 		}
+		rb.add("delete GitHub release "+step.Tag, func() error {
+			return o.githubCmd.DeleteRelease(step.Tag)
+		})
+
+		if !options.Quiet {
+			fmt.Printf("🎉 Created GitHub release for %s\n", j.Version)
+		}
+
+	default:
+		return fmt.Errorf("unknown release step %q", step.Kind)
 	}
 
-	// Success message and next steps
-	o.showSuccessMessage(newVersion, options)
+	return nil
+}
 
+// planSource resolves the version source of a plan again and checks that it
+// still holds the version the plan was computed from.
+func (o *Orchestrator) planSource(plan *Plan) (sources.VersionSource, error) {
+	sourceOption := ""
+	if plan.SourceOption != "" {
+		sourceOption = o.absolutePath(plan.SourceOption)
+	}
+
+	source, sourceFile, err := o.detectVersionSource(sourceOption, plan.SourcePattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect version source: %w", err)
+	}
+	if o.relativePath(sourceFile) != plan.SourceFile {
+		return nil, fmt.Errorf("version source changed since the release was planned: expected %s, found %s", plan.SourceFile, o.relativePath(sourceFile))
+	}
+
+	currentVersion, err := source.GetVersion(sourceFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current version: %w", err)
+	}
+	if currentVersion != plan.PreviousVersion {
+		return nil, fmt.Errorf("%s is at version %s but the release was planned from %s", plan.SourceFile, currentVersion, plan.PreviousVersion)
+	}
+
+	return source, nil
+}
+
+func (o *Orchestrator) image(name string) *sources.ImageUpdater {
+	for _, image := range o.images {
+		if image.Name() == name {
+			return image
+		}
+	}
 	return nil
 }
 
+// releaseChanges lists the commit subjects since the previous release tag. On
+// a first release there is no such tag and no list, as the whole history does
+// not belong in a changelog entry; sources then write their default line.
+func (o *Orchestrator) releaseChanges(previousTag string) ([]string, error) {
+	if !o.gitCmd.TagExists(previousTag) {
+		return nil, nil
+	}
+	return o.gitCmd.CommitSubjects(previousTag)
+}
+
 func (o *Orchestrator) runPreflightChecks(options Options) error {
 	if options.Verbose && !options.Quiet {
 		fmt.Println("🔍 Running pre-flight checks...")
@@ -215,7 +948,11 @@ func (o *Orchestrator) runPreflightChecks(options Options) error {
 	return nil
 }
 
-func (o *Orchestrator) detectVersionSource(sourceFile string) (sources.VersionSource, string, error) {
+func (o *Orchestrator) detectVersionSource(sourceFile, sourcePattern string) (sources.VersionSource, string, error) {
+	if sourcePattern != "" && sourceFile == "" {
+		return nil, "", fmt.Errorf("--source-pattern requires --source to name the file it applies to")
+	}
+
 	if sourceFile != "" {
 		// User specified a source file
 		absPath, err := filepath.Abs(sourceFile)
@@ -223,6 +960,18 @@ func (o *Orchestrator) detectVersionSource(sourceFile string) (sources.VersionSo
 			return nil, "", fmt.Errorf("invalid source file path: %w", err)
 		}
 
+		if sourcePattern != "" {
+			if _, err := os.Stat(absPath); err != nil {
+				return nil, "", fmt.Errorf("file does not exist: %s", absPath)
+			}
+
+			source, err := sources.NewPatternSource(absPath, sourcePattern)
+			if err != nil {
+				return nil, "", err
+			}
+			return source, absPath, nil
+		}
+
 		source, err := o.detector.GetSourceByFile(absPath)
 		if err != nil {
 			return nil, "", err
@@ -231,130 +980,108 @@ func (o *Orchestrator) detectVersionSource(sourceFile string) (sources.VersionSo
 		return source, absPath, nil
 	}
 
-	// Auto-detect
-	cwd := "."
-	source, filePath, err := o.detector.DetectSource(cwd)
-	if err != nil {
+	// Auto-detect from the project root, wherever bumpr was started
+	candidates := o.detector.Resolve(o.root)
+	if len(candidates) == 0 {
 		availableSources := o.detector.ListAvailableSources()
 		return nil, "", fmt.Errorf("no version source file found. Supported files: %s", strings.Join(availableSources, ", "))
 	}
 
-	return source, filePath, nil
+	selected, err := o.detector.Select(candidates)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return selected.Source, selected.File, nil
 }
 
-func (o *Orchestrator) cleanupExistingTag(tagName string, options Options) error {
-	if o.gitCmd.TagExists(tagName) {
-		if options.Verbose && !options.Quiet {
-			fmt.Printf("🧹 Cleaning up existing tag %s...\n", tagName)
+// ShowSources lists every detected version source with its version and marks
+// the one a release would use.
+func (o *Orchestrator) ShowSources() error {
+	candidates := o.detector.DetectAll(o.root)
+	if len(candidates) == 0 {
+		availableSources := o.detector.ListAvailableSources()
+		return fmt.Errorf("no version source file found. Supported files: %s", strings.Join(availableSources, ", "))
+	}
+
+	selected, selectErr := o.detector.Select(candidates)
+
+	fmt.Println("📄 Detected version sources:")
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, candidate := range candidates {
+		marker := " "
+		if selected != nil && candidate.Source == selected.Source {
+			marker = "→"
 		}
-		o.gitCmd.DeleteLocalTag(tagName)
-		if !options.NoPush {
-			o.gitCmd.DeleteRemoteTag(tagName)
+
+		version := candidate.Version
+		if candidate.Err != nil {
+			version = fmt.Sprintf("error: %v", candidate.Err)
 		}
+
+		fmt.Fprintf(w, "%s %s\t%s\t%s\n", marker, candidate.Source.Name(), o.displayPath(candidate.File), version)
+	}
+	w.Flush()
+
+	if selectErr != nil {
+		fmt.Println()
+		fmt.Printf("⚠️  %v\n", selectErr)
 	}
+
 	return nil
 }
 
-func (o *Orchestrator) showDryRunCommands(sourceFile, newVersion string, options Options) {
-	fmt.Println("🔍 Dry run mode - commands that would be executed:")
-	fmt.Println()
-	
-	if options.BumpType == "republish" {
-		if !options.NoPush && o.githubCmd.IsAvailable() {
-			fmt.Printf("→ gh release delete %s --yes (if exists)\n", newVersion)
-		}
-	} else {
-		fmt.Printf("→ Update %s with version %s\n", filepath.Base(sourceFile), newVersion)
-		
-		if !options.NoCommit {
-			fmt.Printf("→ git add %s\n", sourceFile)
-			fmt.Printf("→ git commit -m \"releasing %s\"\n", newVersion)
-			
-			if !options.NoPush {
-				fmt.Println("→ git push origin <current-branch>")
-			}
-		}
+// relativePath returns path relative to the project root, as stored in the journal.
+func (o *Orchestrator) relativePath(path string) string {
+	if relPath, err := filepath.Rel(o.root, path); err == nil {
+		return filepath.ToSlash(relPath)
 	}
-	
-	// Tag cleanup if exists
-	fmt.Printf("→ git tag -d %s (if exists)\n", newVersion)
-	if !options.NoPush {
-		fmt.Printf("→ git push origin --delete %s (if exists)\n", newVersion)
+	return path
+}
+
+func (o *Orchestrator) absolutePath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
 	}
-	
-	fmt.Printf("→ git tag -a %s -m \"Release: %s\"\n", newVersion, newVersion)
-	
-	if !options.NoPush {
-		fmt.Printf("→ git push origin %s --force\n", newVersion)
-		
-		if o.githubCmd.IsAvailable() {
-			fmt.Printf("→ gh release create %s --title \"Release %s\" --notes \"...\"\n", newVersion, newVersion)
-		}
+	return filepath.Join(o.root, filepath.FromSlash(path))
+}
+
+// displayPath shortens paths inside the project root to be relative to it.
+func (o *Orchestrator) displayPath(path string) string {
+	relPath, err := filepath.Rel(o.root, path)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return path
 	}
-	
-	fmt.Println()
-	fmt.Println("Run without --dry-run to execute these commands.")
+	return relPath
 }
 
-func (o *Orchestrator) showSuccessMessage(newVersion string, options Options) {
+func (o *Orchestrator) showSuccessMessage(plan *Plan, options Options) {
 	if options.Quiet {
 		return
 	}
 
 	fmt.Println()
-	fmt.Printf("✅ Successfully released %s\n", newVersion)
+	fmt.Printf("✅ Successfully released %s\n", plan.Version)
 	fmt.Println()
 
-	if options.NoPush {
+	if plan.NoPush {
 		fmt.Println("Next steps:")
 		
-		if !options.NoCommit {
+		if plan.has(StepCommit) {
 			branch, _ := o.gitCmd.CurrentBranch()
-			fmt.Printf("1. Push the commit when ready: git push origin %s\n", branch)
+			fmt.Printf("1. Push the commit when ready: git push %s %s\n", plan.Remote, branch)
 		}
 		
-		fmt.Printf("2. Push the tag when ready: git push origin %s --force\n", newVersion)
-		fmt.Println("3. Create a GitHub release manually or run: gh release create " + newVersion)
+		fmt.Printf("2. Push the tag when ready: git push %s %s\n", plan.Remote, plan.Tag)
+		fmt.Println("3. Create a GitHub release manually or run: gh release create " + plan.Tag)
 	} else {
 		// Everything was pushed automatically
 		fmt.Println("The release process is complete!")
 		fmt.Println()
 		fmt.Println("GitHub Actions is now building your release. You can:")
 		fmt.Println("- Check the build progress in GitHub Actions")
-		fmt.Printf("- View the release at: https://github.com/USERNAME/REPO/releases/tag/%s\n", newVersion)
+		fmt.Printf("- View the release at: https://github.com/USERNAME/REPO/releases/tag/%s\n", plan.Tag)
 	}
 }
-
-func (o *Orchestrator) createGitHubRelease(version string, options Options) error {
-	title := fmt.Sprintf("Release %s", version)
-	notes := fmt.Sprintf("## Release %s\n\nAutomated release created by bumpr.", version)
-	
-	return o.githubCmd.CreateRelease(version, title, notes)
-}
-
-func (o *Orchestrator) forceCleanupTagAndRelease(tagName string, options Options) error {
-	if options.Verbose && !options.Quiet {
-		fmt.Printf("🧹 Force cleaning up tag and release %s...\n", tagName)
-	}
-	
-	// Delete GitHub release first if gh is available
-	if !options.NoPush && o.githubCmd.IsAvailable() {
-		if err := o.githubCmd.DeleteRelease(tagName); err != nil {
-			if options.Verbose && !options.Quiet {
-				fmt.Printf("   Failed to delete GitHub release: %v\n", err)
-			}
-		} else if !options.Quiet {
-			fmt.Printf("🗑️  Deleted GitHub release %s\n", tagName)
-		}
-	}
-	
-	// Delete local tag
-	o.gitCmd.DeleteLocalTag(tagName)
-	
-	// Delete remote tag
-	if !options.NoPush {
-		o.gitCmd.DeleteRemoteTag(tagName)
-	}
-	
-	return nil
-}
\ No newline at end of file
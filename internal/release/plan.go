@@ -0,0 +1,223 @@
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/oriol/bumpr/internal/external"
+)
+
+// StepKind identifies the operation a release step performs.
+type StepKind string
+
+const (
+	StepUpdateFile      StepKind = "update-file"
+	StepUpdateImage     StepKind = "update-image"
+	StepCommit          StepKind = "commit"
+	StepPush            StepKind = "push"
+	StepDeleteRelease   StepKind = "delete-release"
+	StepDeleteTag       StepKind = "delete-tag"
+	StepDeleteRemoteTag StepKind = "delete-remote-tag"
+	StepTag             StepKind = "tag"
+	StepPushTag         StepKind = "push-tag"
+	StepGitHubRelease   StepKind = "github-release"
+)
+
+// Step is one operation of a release, with every argument resolved. Files are
+// relative to the project root. A push step with a Tag pushes the branch and
+// the tag atomically.
+type Step struct {
+	Kind    StepKind `json:"kind"`
+	Files   []string `json:"files,omitempty"`
+	Image   string   `json:"image,omitempty"`
+	Version string   `json:"version,omitempty"`
+	Message string   `json:"message,omitempty"`
+	Remote  string   `json:"remote,omitempty"`
+	Branch  string   `json:"branch,omitempty"`
+	Target  string   `json:"target,omitempty"`
+	Tag     string   `json:"tag,omitempty"`
+	Object  string   `json:"object,omitempty"`
+	Sign    bool     `json:"sign,omitempty"`
+	Key     string   `json:"key,omitempty"`
+	Title   string   `json:"title,omitempty"`
+	Notes   string   `json:"notes,omitempty"`
+}
+
+// Plan is a fully computed release: dry-run prints it, `bumpr plan` saves it
+// and `bumpr apply` executes it as is.
+type Plan struct {
+	BumpType        string `json:"bump_type"`
+	PreviousVersion string `json:"previous_version"`
+	Version         string `json:"version"`
+	Tag             string `json:"tag"`
+	PreviousTag     string `json:"previous_tag"`
+	SourceName      string `json:"source_name"`
+	SourceFile      string `json:"source_file"`
+	SourceOption    string `json:"source_option,omitempty"`
+	SourcePattern   string `json:"source_pattern,omitempty"`
+	NoCommit        bool   `json:"no_commit,omitempty"`
+	NoPush          bool   `json:"no_push,omitempty"`
+	Remote          string `json:"remote"`
+	PreviousHead    string `json:"previous_head"`
+	GitBackend      string `json:"git_backend,omitempty"`
+	Steps           []Step `json:"steps"`
+}
+
+// LoadPlan reads a plan saved with Save.
+func LoadPlan(path string) (*Plan, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+
+	plan := &Plan{}
+	if err := json.Unmarshal(content, plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan %s: %w", path, err)
+	}
+	if plan.Version == "" || plan.PreviousHead == "" || len(plan.Steps) == 0 {
+		return nil, fmt.Errorf("%s is not a bumpr release plan", path)
+	}
+	return plan, nil
+}
+
+func (p *Plan) Save(path string) error {
+	content, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode plan: %w", err)
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+	return nil
+}
+
+// Print lists the steps of the plan, with the exact commands they run.
+func (p *Plan) Print() {
+	for _, line := range p.lines() {
+		fmt.Printf("→ %s\n", line)
+	}
+}
+
+// lines describes every step. The go-git backend runs no git commands, so
+// their lines show the operation it performs instead.
+func (p *Plan) lines() []string {
+	var lines []string
+	for _, step := range p.Steps {
+		for _, line := range step.Lines() {
+			if p.GitBackend == external.BackendGoGit && strings.HasPrefix(line, "git ") {
+				line = "go-git " + strings.TrimPrefix(line, "git ")
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func (p *Plan) has(kind StepKind) bool {
+	return p.step(kind) != nil
+}
+
+func (p *Plan) step(kind StepKind) *Step {
+	for i := range p.Steps {
+		if p.Steps[i].Kind == kind {
+			return &p.Steps[i]
+		}
+	}
+	return nil
+}
+
+// signingKey reports whether the plan signs its tag, and with which key.
+func (p *Plan) signingKey() (key string, sign bool) {
+	if tag := p.step(StepTag); tag != nil {
+		return tag.Key, tag.Sign
+	}
+	return "", false
+}
+
+// files lists the files the plan updates.
+func (p *Plan) files() []string {
+	var files []string
+	for _, step := range p.Steps {
+		if step.Kind == StepUpdateFile || step.Kind == StepUpdateImage {
+			files = append(files, step.Files...)
+		}
+	}
+	return files
+}
+
+// Commands returns the command lines the step runs, nil for file updates.
+func (s Step) Commands() [][]string {
+	switch s.Kind {
+	case StepCommit:
+		commit := []string{"git", "commit", "-m", s.Message}
+		if s.Sign {
+			commit = []string{"git", "commit", "-S" + s.Key, "-m", s.Message}
+		}
+		return [][]string{append([]string{"git", "add"}, s.Files...), commit}
+	case StepPush:
+		if s.Tag != "" {
+			return [][]string{{"git", "push", "--atomic", s.Remote, external.PushRefspec(s.Branch, s.Target), s.Tag}}
+		}
+		return [][]string{{"git", "push", s.Remote, external.PushRefspec(s.Branch, s.Target)}}
+	case StepDeleteRelease:
+		return [][]string{{"gh", "release", "delete", s.Tag, "--yes"}}
+	case StepDeleteTag:
+		return [][]string{{"git", "tag", "-d", s.Tag}}
+	case StepDeleteRemoteTag:
+		remove := []string{"git", "push", s.Remote, "--delete", s.Tag}
+		if s.Object == "" {
+			return [][]string{remove}
+		}
+		return [][]string{{"git", "fetch", "--no-tags", s.Remote, "refs/tags/" + s.Tag}, remove}
+	case StepTag:
+		if !s.Sign {
+			return [][]string{{"git", "tag", "-a", s.Tag, "-m", s.Message}}
+		}
+		tag := []string{"git", "tag", "-s"}
+		if s.Key != "" {
+			tag = append(tag, "-u", s.Key)
+		}
+		return [][]string{append(tag, s.Tag, "-m", s.Message), {"git", "verify-tag", s.Tag}}
+	case StepPushTag:
+		return [][]string{{"git", "push", s.Remote, s.Tag}}
+	case StepGitHubRelease:
+		return [][]string{{"gh", "release", "create", s.Tag, "--title", s.Title, "--notes", s.Notes}}
+	}
+	return nil
+}
+
+// Lines describes the step for display, as shell commands where it runs any.
+func (s Step) Lines() []string {
+	switch s.Kind {
+	case StepUpdateFile:
+		return []string{fmt.Sprintf("Update %s with version %s", strings.Join(s.Files, ", "), s.Version)}
+	case StepUpdateImage:
+		return []string{fmt.Sprintf("Update image %s to version %s in %s", s.Image, s.Version, strings.Join(s.Files, ", "))}
+	}
+
+	var lines []string
+	for _, command := range s.Commands() {
+		quoted := make([]string, len(command))
+		for i, arg := range command {
+			quoted[i] = shellQuote(arg)
+		}
+		lines = append(lines, strings.Join(quoted, " "))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, fmt.Sprintf("unknown step %q", s.Kind))
+	}
+	return lines
+}
+
+func shellQuote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`!*?[]{}()<>|&;#~") {
+		return arg
+	}
+	if strings.ContainsAny(arg, "\n\t") {
+		escaper := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\t", `\t`)
+		return "$'" + escaper.Replace(arg) + "'"
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
@@ -0,0 +1,401 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oriol/bumpr/internal/config"
+	"github.com/oriol/bumpr/internal/external"
+)
+
+// recordingRunner answers the queries of a release and records every other
+// command, i.e. the ones that change the repository. With noAtomic, atomic
+// pushes fail like on a remote that does not support them, and the command
+// fail is recorded but fails. With detached, HEAD is on no branch, and log
+// is the output of git log.
+type recordingRunner struct {
+	gitDir   string
+	tags     map[string]bool
+	noAtomic bool
+	detached bool
+	fail     string
+	log      string
+	commands [][]string
+}
+
+func (r *recordingRunner) Run(ctx context.Context, cmd string, args ...string) (*external.CommandResult, error) {
+	return r.RunWithOutput(ctx, cmd, args...)
+}
+
+func (r *recordingRunner) RunWithInput(ctx context.Context, input string, cmd string, args ...string) (*external.CommandResult, error) {
+	return r.RunWithOutput(ctx, cmd, args...)
+}
+
+func (r *recordingRunner) RunWithOutput(ctx context.Context, cmd string, args ...string) (*external.CommandResult, error) {
+	result := &external.CommandResult{Command: cmd, Args: args}
+	query := strings.Join(append([]string{cmd}, args...), " ")
+
+	switch {
+	case query == "git rev-parse HEAD":
+		result.Stdout = "0123456789abcdef\n"
+	case query == "git rev-parse --abbrev-ref HEAD" && r.detached:
+		result.Stdout = "HEAD\n"
+	case query == "git rev-parse --abbrev-ref HEAD":
+		result.Stdout = "main\n"
+	case query == "git rev-parse --absolute-git-dir", query == "git rev-parse --git-dir":
+		result.Stdout = r.gitDir + "\n"
+	case strings.HasPrefix(query, "git rev-parse "):
+		tag := strings.TrimSuffix(strings.TrimPrefix(args[len(args)-1], "refs/tags/"), "^{commit}")
+		if !r.tags[tag] {
+			return result, fmt.Errorf("unknown revision %s", args[len(args)-1])
+		}
+		result.Stdout = "fedcba9876543210\n"
+	case strings.HasPrefix(query, "git ls-remote "):
+		tag := strings.TrimPrefix(args[3], "refs/tags/")
+		if r.tags[tag] {
+			result.Stdout = "fedcba9876543210\trefs/tags/" + tag + "\n"
+		}
+	case r.noAtomic && strings.HasPrefix(query, "git push --atomic "):
+		result.Stderr = "fatal: the receiving end does not support --atomic push\n"
+		return result, fmt.Errorf("exit status 128")
+	case strings.HasPrefix(query, "git config --get "):
+		return result, fmt.Errorf("not set")
+	case strings.HasPrefix(query, "git log "):
+		result.Stdout = r.log
+	case strings.HasSuffix(query, " --version"), strings.HasPrefix(query, "gh release view "),
+		strings.HasPrefix(query, "git status "):
+	case query == r.fail:
+		r.commands = append(r.commands, append([]string{cmd}, args...))
+		return result, fmt.Errorf("exit status 1")
+	default:
+		r.commands = append(r.commands, append([]string{cmd}, args...))
+	}
+	return result, nil
+}
+
+func TestOrchestrator_ExecutesThePlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		tags    map[string]bool
+		want    []StepKind
+		wantErr string
+	}{
+		{
+			name:    "patch",
+			options: Options{BumpType: "patch", TagPrefix: "v"},
+			want:    []StepKind{StepUpdateFile, StepCommit, StepPush, StepTag, StepPushTag, StepGitHubRelease},
+		},
+		{
+			name:    "existing tag is a conflict",
+			options: Options{BumpType: "minor", TagPrefix: "v"},
+			tags:    map[string]bool{"v1.3.0": true},
+			wantErr: "tag v1.3.0 already exists locally and on origin, both at fedcba987654",
+		},
+		{
+			name:    "existing tag is replaced on request",
+			options: Options{BumpType: "minor", TagPrefix: "v", ReplaceTag: true},
+			tags:    map[string]bool{"v1.3.0": true},
+			want:    []StepKind{StepUpdateFile, StepCommit, StepPush, StepDeleteTag, StepDeleteRemoteTag, StepTag, StepPushTag, StepGitHubRelease},
+		},
+		{
+			name:    "republish",
+			options: Options{BumpType: "republish", TagPrefix: "v"},
+			tags:    map[string]bool{"v1.2.3": true},
+			want:    []StepKind{StepDeleteRelease, StepDeleteTag, StepDeleteRemoteTag, StepTag, StepPushTag, StepGitHubRelease},
+		},
+		{
+			name:    "no push",
+			options: Options{BumpType: "major", NoPush: true},
+			want:    []StepKind{StepUpdateFile, StepCommit, StepTag},
+		},
+		{
+			name:    "atomic push",
+			options: Options{BumpType: "patch", TagPrefix: "v", AtomicPush: true},
+			want:    []StepKind{StepUpdateFile, StepCommit, StepTag, StepPush, StepGitHubRelease},
+		},
+		{
+			name:    "atomic push replacing a tag",
+			options: Options{BumpType: "minor", TagPrefix: "v", AtomicPush: true, ReplaceTag: true},
+			tags:    map[string]bool{"v1.3.0": true},
+			want:    []StepKind{StepUpdateFile, StepCommit, StepDeleteTag, StepDeleteRemoteTag, StepTag, StepPush, StepGitHubRelease},
+		},
+		{
+			name:    "signed",
+			options: Options{BumpType: "patch", TagPrefix: "v", Sign: true, SigningKey: "ABCDEF12"},
+			want:    []StepKind{StepUpdateFile, StepCommit, StepPush, StepTag, StepPushTag, StepGitHubRelease},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if err := os.WriteFile(filepath.Join(root, ".version"), []byte("1.2.3\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			runner := &recordingRunner{gitDir: filepath.Join(root, ".git"), tags: tt.tags}
+			orchestrator, err := NewOrchestrator(runner, &config.Config{}, root, false)
+			if err != nil {
+				t.Fatalf("NewOrchestrator() error = %v", err)
+			}
+
+			options := tt.options
+			options.Quiet = true
+			options.Force = true
+
+			plan, err := orchestrator.Plan(options)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Plan() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Plan() error = %v", err)
+			}
+
+			var kinds []StepKind
+			var want [][]string
+			for _, step := range plan.Steps {
+				kinds = append(kinds, step.Kind)
+				want = append(want, step.Commands()...)
+			}
+			if !reflect.DeepEqual(kinds, tt.want) {
+				t.Fatalf("Plan() steps = %v, want %v", kinds, tt.want)
+			}
+			if len(runner.commands) > 0 {
+				t.Fatalf("Plan() ran %v", runner.commands)
+			}
+
+			if err := orchestrator.Execute(options); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !reflect.DeepEqual(runner.commands, want) {
+				t.Errorf("Execute() ran\n%v\nwant the planned\n%v", runner.commands, want)
+			}
+		})
+	}
+}
+
+func TestOrchestrator_AtomicPushFallback(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".version"), []byte("1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	runner := &recordingRunner{gitDir: filepath.Join(root, ".git"), noAtomic: true}
+	orchestrator, err := NewOrchestrator(runner, &config.Config{}, root, false)
+	if err != nil {
+		t.Fatalf("NewOrchestrator() error = %v", err)
+	}
+
+	err = orchestrator.Execute(Options{BumpType: "patch", TagPrefix: "v", Quiet: true, Force: true, AtomicPush: true})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var pushes [][]string
+	for _, command := range runner.commands {
+		if len(command) > 1 && command[1] == "push" {
+			pushes = append(pushes, command)
+		}
+	}
+	want := [][]string{{"git", "push", "origin", "main"}, {"git", "push", "origin", "v1.2.4"}}
+	if !reflect.DeepEqual(pushes, want) {
+		t.Errorf("Execute() pushed %v, want %v", pushes, want)
+	}
+}
+
+func TestOrchestrator_RefusesDetachedHead(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+	}{
+		{name: "no push", options: Options{BumpType: "patch", NoPush: true}},
+		{name: "force", options: Options{BumpType: "patch", Force: true}},
+		{name: "no commit", options: Options{BumpType: "patch", NoCommit: true, NoPush: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if err := os.WriteFile(filepath.Join(root, ".version"), []byte("1.2.3\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			runner := &recordingRunner{gitDir: filepath.Join(root, ".git"), detached: true}
+			orchestrator, err := NewOrchestrator(runner, &config.Config{}, root, false)
+			if err != nil {
+				t.Fatalf("NewOrchestrator() error = %v", err)
+			}
+
+			options := tt.options
+			options.Quiet = true
+			err = orchestrator.Execute(options)
+			if err == nil || !strings.Contains(err.Error(), "HEAD is detached") {
+				t.Fatalf("Execute() error = %v, want a detached HEAD refusal", err)
+			}
+			if len(runner.commands) > 0 {
+				t.Errorf("Execute() ran %v", runner.commands)
+			}
+		})
+	}
+}
+
+func TestOrchestrator_ReleaseChanges(t *testing.T) {
+	tests := []struct {
+		name string
+		tags map[string]bool
+		want []string
+	}{
+		{name: "since the previous tag", tags: map[string]bool{"v1.2.3": true}, want: []string{"Fix parsing", "Add export"}},
+		{name: "first release", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			runner := &recordingRunner{gitDir: filepath.Join(root, ".git"), tags: tt.tags, log: "Fix parsing\nAdd export\n"}
+			orchestrator, err := NewOrchestrator(runner, &config.Config{}, root, false)
+			if err != nil {
+				t.Fatalf("NewOrchestrator() error = %v", err)
+			}
+
+			got, err := orchestrator.releaseChanges("v1.2.3")
+			if err != nil {
+				t.Fatalf("releaseChanges() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("releaseChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrchestrator_RollbackRestoresReplacedTags(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".version"), []byte("1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	runner := &recordingRunner{gitDir: filepath.Join(root, ".git"), tags: map[string]bool{"v1.3.0": true}, fail: "git push origin v1.3.0"}
+	orchestrator, err := NewOrchestrator(runner, &config.Config{}, root, false)
+	if err != nil {
+		t.Fatalf("NewOrchestrator() error = %v", err)
+	}
+
+	err = orchestrator.Execute(Options{BumpType: "minor", TagPrefix: "v", Quiet: true, Force: true, ReplaceTag: true})
+	if err == nil {
+		t.Fatal("Execute() error = nil with a failing tag push")
+	}
+
+	want := [][]string{
+		{"git", "tag", "-d", "v1.3.0"},
+		{"git", "push", "origin", "fedcba9876543210:refs/tags/v1.3.0"},
+		{"git", "update-ref", "refs/tags/v1.3.0", "fedcba9876543210"},
+	}
+	undone := runner.commands[len(runner.commands)-len(want):]
+	if !reflect.DeepEqual(undone, want) {
+		t.Errorf("rollback ran\n%v\nwant\n%v", undone, want)
+	}
+}
+
+func TestPlan_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+
+	plan := testPlan()
+	plan.PreviousHead = "0123456789abcdef"
+	if err := plan.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadPlan(path)
+	if err != nil {
+		t.Fatalf("LoadPlan() error = %v", err)
+	}
+	if !reflect.DeepEqual(*loaded, plan) {
+		t.Errorf("LoadPlan() = %+v, want %+v", loaded, plan)
+	}
+
+	os.WriteFile(path, []byte(`{"name": "not a plan"}`), 0644)
+	if _, err := LoadPlan(path); err == nil {
+		t.Error("LoadPlan() should reject a file that is not a plan")
+	}
+}
+
+func TestPlan_LinesOfTheGoGitBackend(t *testing.T) {
+	plan := testPlan()
+	plan.GitBackend = external.BackendGoGit
+	plan.Steps = append(plan.Steps, Step{Kind: StepGitHubRelease, Tag: "v1.2.4", Title: "Release 1.2.4", Notes: "Notes"})
+
+	want := []string{
+		"Update .version with version 1.2.4",
+		"go-git add .version",
+		"go-git commit -m 'releasing 1.2.4'",
+		"go-git push origin main",
+		"go-git tag -a v1.2.4 -m 'Release: 1.2.4'",
+		"go-git push origin v1.2.4",
+		"gh release create v1.2.4 --title 'Release 1.2.4' --notes Notes",
+	}
+	if got := plan.lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("lines() = %q, want %q", got, want)
+	}
+}
+
+func TestStep_Lines(t *testing.T) {
+	tests := []struct {
+		step Step
+		want []string
+	}{
+		{
+			step: Step{Kind: StepCommit, Files: []string{".version", "deploy/app.yaml"}, Message: "releasing 1.2.4"},
+			want: []string{"git add .version deploy/app.yaml", "git commit -m 'releasing 1.2.4'"},
+		},
+		{
+			step: Step{Kind: StepCommit, Files: []string{".version"}, Message: "releasing 1.2.4", Sign: true, Key: "ABCDEF12"},
+			want: []string{"git add .version", "git commit -SABCDEF12 -m 'releasing 1.2.4'"},
+		},
+		{
+			step: Step{Kind: StepTag, Tag: "v1.2.4", Message: "Release: 1.2.4", Sign: true},
+			want: []string{"git tag -s v1.2.4 -m 'Release: 1.2.4'", "git verify-tag v1.2.4"},
+		},
+		{
+			step: Step{Kind: StepPush, Remote: "origin", Branch: "release/1.x", Target: "release/1.x"},
+			want: []string{"git push origin release/1.x"},
+		},
+		{
+			step: Step{Kind: StepPush, Remote: "upstream", Branch: "hotfix", Target: "main"},
+			want: []string{"git push upstream hotfix:main"},
+		},
+		{
+			step: Step{Kind: StepPush, Remote: "origin", Branch: "main", Target: "main", Tag: "v1.2.4"},
+			want: []string{"git push --atomic origin main v1.2.4"},
+		},
+		{
+			step: Step{Kind: StepDeleteRemoteTag, Remote: "origin", Tag: "v1.2.4", Object: "fedcba9876543210"},
+			want: []string{"git fetch --no-tags origin refs/tags/v1.2.4", "git push origin --delete v1.2.4"},
+		},
+		{
+			step: Step{Kind: StepGitHubRelease, Tag: "v1.2.4", Title: "Release 1.2.4", Notes: "## Release 1.2.4\n\nIt's out."},
+			want: []string{`gh release create v1.2.4 --title 'Release 1.2.4' --notes $'## Release 1.2.4\n\nIt\'s out.'`},
+		},
+		{
+			step: Step{Kind: StepUpdateFile, Files: []string{"package.json"}, Version: "2.0.0"},
+			want: []string{"Update package.json with version 2.0.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.step.Kind), func(t *testing.T) {
+			if got := tt.step.Lines(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oriol/bumpr/internal/config"
+)
+
+// checkBranchPolicy enforces the branch rules of the configuration for the
+// current branch. options.AllowAnyBranch lifts them, which verbose output
+// records.
+func (o *Orchestrator) checkBranchPolicy(options Options) error {
+	if len(o.branchRules) == 0 {
+		return nil
+	}
+
+	branch, err := o.gitCmd.CurrentBranch()
+	if err != nil {
+		return fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	err = checkBranchRules(o.branchRules, branch, options.BumpType)
+	verbose := options.Verbose && !options.Quiet
+
+	if options.AllowAnyBranch {
+		if verbose {
+			if err != nil {
+				fmt.Printf("🔓 Branch policy overridden with --allow-any-branch: %v\n", err)
+			} else {
+				fmt.Printf("🔓 Branch policy overridden with --allow-any-branch (%s is allowed anyway)\n", branch)
+			}
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w. Pass --allow-any-branch to override", err)
+	}
+
+	if verbose {
+		fmt.Printf("✅ Branch %s is allowed for %s releases\n", branch, options.BumpType)
+	}
+	return nil
+}
+
+// checkBranchRules returns an error listing the allowed branches when no rule
+// allows bumpType releases from branch.
+func checkBranchRules(rules []config.BranchRule, branch, bumpType string) error {
+	var allowed []string
+	for _, rule := range rules {
+		if !rule.Allows(bumpType) {
+			continue
+		}
+		if rule.Matches(branch) {
+			return nil
+		}
+		allowed = append(allowed, rule.Pattern)
+	}
+
+	if branch == "HEAD" {
+		branch = "a detached HEAD"
+	} else {
+		branch = "branch " + branch
+	}
+
+	if len(allowed) == 0 {
+		return fmt.Errorf("%s releases are not allowed from any branch by the branch rules", bumpType)
+	}
+	return fmt.Errorf("%s releases are not allowed from %s, only from %s", bumpType, branch, strings.Join(allowed, ", "))
+}
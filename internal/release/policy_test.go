@@ -0,0 +1,44 @@
+package release
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oriol/bumpr/internal/config"
+)
+
+func TestCheckBranchRules(t *testing.T) {
+	rules := []config.BranchRule{
+		{Pattern: "main"},
+		{Pattern: "release/*"},
+		{Pattern: "hotfix/*", Bump: []string{"patch"}},
+	}
+
+	tests := []struct {
+		branch   string
+		bumpType string
+		wantErr  string
+	}{
+		{branch: "main", bumpType: "major"},
+		{branch: "release/2.x", bumpType: "minor"},
+		{branch: "hotfix/login", bumpType: "patch"},
+		{branch: "hotfix/login", bumpType: "minor", wantErr: "minor releases are not allowed from branch hotfix/login, only from main, release/*"},
+		{branch: "feature/x", bumpType: "patch", wantErr: "only from main, release/*, hotfix/*"},
+		{branch: "HEAD", bumpType: "patch", wantErr: "not allowed from a detached HEAD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch+" "+tt.bumpType, func(t *testing.T) {
+			err := checkBranchRules(rules, tt.branch, tt.bumpType)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkBranchRules() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkBranchRules() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package release
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Interactive reports whether bumpr may ask questions: stdin and stdout are
+// terminals and it does not run in CI.
+func Interactive() bool {
+	if ci := os.Getenv("CI"); ci != "" && ci != "false" && ci != "0" {
+		return false
+	}
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Prompter asks questions and reads the answers line by line.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out}
+}
+
+// Confirm asks a yes/no question. Anything but yes, including no input at
+// all, is a no.
+func (p *Prompter) Confirm(question string) (bool, error) {
+	fmt.Fprintf(p.out, "%s [y/N] ", question)
+
+	answer, err := p.readLine()
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+// Choose shows a numbered menu and returns the index of the chosen entry.
+// An empty answer picks the first one.
+func (p *Prompter) Choose(question string, choices []string) (int, error) {
+	fmt.Fprintln(p.out, question)
+	for i, choice := range choices {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, choice)
+	}
+
+	for {
+		fmt.Fprintf(p.out, "Choice [1]: ")
+
+		answer, err := p.readLine()
+		if err != nil {
+			return 0, err
+		}
+		if answer == "" {
+			return 0, nil
+		}
+
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 1 && n <= len(choices) {
+			return n - 1, nil
+		}
+		fmt.Fprintf(p.out, "Please enter a number between 1 and %d.\n", len(choices))
+	}
+}
+
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return "", fmt.Errorf("no answer given")
+	}
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
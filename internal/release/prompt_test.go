@@ -0,0 +1,95 @@
+package release
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oriol/bumpr/internal/config"
+)
+
+func TestPrompter_Confirm(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    bool
+		wantErr bool
+	}{
+		{input: "y\n", want: true},
+		{input: "YES\n", want: true},
+		{input: "n\n", want: false},
+		{input: "\n", want: false},
+		{input: "sure\n", want: false},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.input), func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := NewPrompter(strings.NewReader(tt.input), &out).Confirm("Proceed?")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Confirm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Confirm() = %v, want %v", got, tt.want)
+			}
+			if !strings.Contains(out.String(), "Proceed? [y/N]") {
+				t.Errorf("Confirm() printed %q", out.String())
+			}
+		})
+	}
+}
+
+func TestPrompter_Choose(t *testing.T) {
+	choices := []string{"patch", "minor", "major"}
+
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{input: "\n", want: 0},
+		{input: "3\n", want: 2},
+		{input: "7\nminor\n2\n", want: 1},
+		{input: "9\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.input), func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := NewPrompter(strings.NewReader(tt.input), &out).Choose("What kind of release?", choices)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Choose() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Choose() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrchestrator_ConfirmDeclined(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".version"), []byte("1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	runner := &recordingRunner{gitDir: filepath.Join(root, ".git")}
+	orchestrator, err := NewOrchestrator(runner, &config.Config{}, root, false)
+	if err != nil {
+		t.Fatalf("NewOrchestrator() error = %v", err)
+	}
+	orchestrator.prompter = NewPrompter(strings.NewReader("n\n"), &bytes.Buffer{})
+
+	err = orchestrator.Execute(Options{BumpType: "patch", Quiet: true, Force: true, Confirm: true})
+	if err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Fatalf("Execute() error = %v, want cancelled", err)
+	}
+	if len(runner.commands) > 0 {
+		t.Errorf("Execute() ran %v after the release was declined", runner.commands)
+	}
+	if content, _ := os.ReadFile(filepath.Join(root, ".version")); string(content) != "1.2.3\n" {
+		t.Errorf(".version = %q, want it untouched", content)
+	}
+}
@@ -0,0 +1,181 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (o *Orchestrator) loadJournal() (*journal, error) {
+	gitDir, err := o.gitCmd.GitDir()
+	if err != nil {
+		// Not a repository: there cannot be a release in progress
+		return nil, nil
+	}
+	return loadJournal(journalPath(gitDir))
+}
+
+// Resume finishes an interrupted release, skipping the steps the journal
+// lists as completed. The version is not bumped again.
+func (o *Orchestrator) Resume(options Options) error {
+	j, err := o.loadJournal()
+	if err != nil {
+		return err
+	}
+	if j == nil {
+		return fmt.Errorf("no interrupted release to resume")
+	}
+
+	if !options.Quiet {
+		fmt.Printf("🔁 Resuming release of %s", j.Version)
+		if j.Completed > 0 {
+			fmt.Printf(" (done: %s)", strings.Join(j.completedKinds(), ", "))
+		}
+		fmt.Printf("\n\n")
+	}
+
+	// Steps must continue from the state they left the repository in
+	head, err := o.gitCmd.Head()
+	if err != nil {
+		return fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	expected := j.PreviousHead
+	if j.done(StepCommit) {
+		expected = j.ReleaseCommit
+	}
+	if head != expected {
+		return fmt.Errorf("HEAD moved since the release of %s was interrupted (expected %s, found %s). Run 'bumpr abort' to discard it", j.Version, shortCommit(expected), shortCommit(head))
+	}
+
+	// The tag is created again, so that it is verified again
+	if o.leftoverTag(j) {
+		if err := o.gitCmd.DeleteLocalTag(j.Tag); err != nil {
+			return fmt.Errorf("failed to delete tag %s: %w", j.Tag, err)
+		}
+	}
+
+	return o.run(j, options)
+}
+
+// leftoverTag reports whether the tag of the release exists at the commit it
+// is meant for although the tag step did not complete. The step then failed
+// after creating the tag, e.g. in verification, and was not rolled back.
+func (o *Orchestrator) leftoverTag(j *journal) bool {
+	if !j.tagPending() {
+		return false
+	}
+
+	target := j.PreviousHead
+	if j.done(StepCommit) {
+		target = j.ReleaseCommit
+	}
+	_, commit, ok := o.gitCmd.LocalTag(j.Tag)
+	return ok && commit == target
+}
+
+// Abort discards an interrupted release: the GitHub release and tags it
+// created are deleted, the tags it replaced are restored, an unpushed release
+// commit is reset and the files it changed are restored. A release commit
+// that was already pushed is kept. Every step tolerates having been undone
+// before, so Abort can be re-run.
+func (o *Orchestrator) Abort(options Options) error {
+	j, err := o.loadJournal()
+	if err != nil {
+		return err
+	}
+	if j == nil {
+		return fmt.Errorf("no interrupted release to abort")
+	}
+
+	if !options.Quiet {
+		fmt.Printf("🧹 Aborting release of %s...\n", j.Version)
+	}
+
+	var failures []string
+	undo := func(description string, action func() error) {
+		if err := action(); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", description, err))
+			if !options.Quiet {
+				fmt.Printf("   ❌ %s: %v\n", description, err)
+			}
+			return
+		}
+		if !options.Quiet {
+			fmt.Printf("   ✅ %s\n", description)
+		}
+	}
+
+	if j.done(StepGitHubRelease) && o.githubCmd.IsAvailable() {
+		undo("delete GitHub release "+j.Tag, func() error {
+			return o.githubCmd.DeleteRelease(j.Tag)
+		})
+	}
+	if j.tagPushed() {
+		undo("delete remote tag "+j.Tag, func() error {
+			return o.gitCmd.DeleteRemoteTag(j.Remote, j.Tag)
+		})
+	}
+	if j.done(StepTag) || o.leftoverTag(j) {
+		undo("delete tag "+j.Tag, func() error {
+			return o.gitCmd.DeleteLocalTag(j.Tag)
+		})
+	}
+	if step := j.step(StepDeleteRemoteTag); step != nil && step.Object != "" && j.done(StepDeleteRemoteTag) {
+		undo("restore remote tag "+j.Tag, func() error {
+			return o.gitCmd.RestoreRemoteTag(step.Remote, step.Tag, step.Object)
+		})
+	}
+	if step := j.step(StepDeleteTag); step != nil && step.Object != "" && j.done(StepDeleteTag) {
+		undo("restore tag "+j.Tag, func() error {
+			return o.gitCmd.RestoreTag(step.Tag, step.Object)
+		})
+	}
+
+	switch {
+	case j.done(StepPush):
+		if !options.Quiet {
+			push := j.step(StepPush)
+			fmt.Printf("   ℹ️  release commit %s is already on %s/%s and is kept\n", shortCommit(j.ReleaseCommit), push.Remote, push.Target)
+		}
+	case len(j.updatedFiles()) > 0:
+		if j.done(StepCommit) {
+			undo("reset release commit", func() error {
+				// Never drop commits made on top of the release commit
+				if head, err := o.gitCmd.Head(); err != nil || head != j.ReleaseCommit {
+					return fmt.Errorf("HEAD is no longer the release commit %s, reset it by hand", shortCommit(j.ReleaseCommit))
+				}
+				return o.gitCmd.Reset(j.PreviousHead)
+			})
+		}
+		if len(failures) > 0 {
+			break
+		}
+
+		var files []string
+		for _, file := range j.updatedFiles() {
+			files = append(files, o.absolutePath(file))
+		}
+		undo("restore "+strings.Join(j.updatedFiles(), ", "), func() error {
+			return o.gitCmd.RestoreFiles(j.PreviousHead, files...)
+		})
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to abort the release of %s, fix the errors and re-run 'bumpr abort':\n  %s", j.Version, strings.Join(failures, "\n  "))
+	}
+
+	if err := j.remove(); err != nil {
+		return err
+	}
+
+	if !options.Quiet {
+		fmt.Printf("✅ Release of %s aborted\n", j.Version)
+	}
+	return nil
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
@@ -0,0 +1,88 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// rollback collects the compensating actions of completed release steps, to
+// run them in reverse order when a later step fails.
+type rollback struct {
+	actions []compensation
+}
+
+type compensation struct {
+	description string
+	undo        func() error
+}
+
+func (r *rollback) add(description string, undo func() error) {
+	r.actions = append(r.actions, compensation{description: description, undo: undo})
+}
+
+// keep forgets the registered actions once their effects have been published
+// and can no longer be undone safely, e.g. after pushing the release commit.
+func (r *rollback) keep() {
+	r.actions = nil
+}
+
+// restoreFile snapshots a file so it can be restored if the release fails.
+func (r *rollback) restoreFile(path, displayPath string) error {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		r.add("remove "+displayPath, func() error {
+			return os.Remove(path)
+		})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	r.add("restore "+displayPath, func() error {
+		return os.WriteFile(path, content, info.Mode().Perm())
+	})
+	return nil
+}
+
+// run undoes the registered actions, newest first. Every action is attempted;
+// the failures are returned together.
+func (r *rollback) run(quiet bool) error {
+	if len(r.actions) > 0 && !quiet {
+		fmt.Println()
+		fmt.Println("↩️  Rolling back...")
+	}
+
+	var errs []error
+	for i := len(r.actions) - 1; i >= 0; i-- {
+		action := r.actions[i]
+		if err := action.undo(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to %s: %w", action.description, err))
+			if !quiet {
+				fmt.Printf("   ❌ %s: %v\n", action.description, err)
+			}
+			continue
+		}
+		if !quiet {
+			fmt.Printf("   ✅ %s\n", action.description)
+		}
+	}
+	r.actions = nil
+
+	return errors.Join(errs...)
+}
+
+// pending describes the actions that were not run, newest first.
+func (r *rollback) pending() []string {
+	var descriptions []string
+	for i := len(r.actions) - 1; i >= 0; i-- {
+		descriptions = append(descriptions, r.actions[i].description)
+	}
+	return descriptions
+}
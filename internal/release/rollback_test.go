@@ -0,0 +1,87 @@
+package release
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRollback_RunsInReverseOrder(t *testing.T) {
+	var order []string
+	rb := &rollback{}
+	for _, name := range []string{"first", "second", "third"} {
+		rb.add(name, func() error {
+			order = append(order, name)
+			if name == "second" {
+				return errors.New("boom")
+			}
+			return nil
+		})
+	}
+
+	if got := strings.Join(rb.pending(), ","); got != "third,second,first" {
+		t.Errorf("pending() = %s, want third,second,first", got)
+	}
+
+	err := rb.run(true)
+	if err == nil || !strings.Contains(err.Error(), "failed to second: boom") {
+		t.Errorf("run() error = %v, want the failing action", err)
+	}
+	if got := strings.Join(order, ","); got != "third,second,first" {
+		t.Errorf("actions ran as %s, want third,second,first", got)
+	}
+	if len(rb.pending()) != 0 {
+		t.Error("run() should clear the actions")
+	}
+}
+
+func TestRollback_Keep(t *testing.T) {
+	ran := false
+	rb := &rollback{}
+	rb.add("reset release commit", func() error {
+		ran = true
+		return nil
+	})
+	rb.keep()
+
+	if err := rb.run(true); err != nil || ran {
+		t.Errorf("run() after keep() = %v, ran = %v", err, ran)
+	}
+}
+
+func TestRollback_RestoreFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	existing := filepath.Join(tmpDir, ".version")
+	created := filepath.Join(tmpDir, "CHANGELOG.md")
+	if err := os.WriteFile(existing, []byte("1.0.0\n"), 0600); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	rb := &rollback{}
+	if err := rb.restoreFile(existing, ".version"); err != nil {
+		t.Fatalf("restoreFile() error = %v", err)
+	}
+	if err := rb.restoreFile(created, "CHANGELOG.md"); err != nil {
+		t.Fatalf("restoreFile() error = %v", err)
+	}
+
+	os.WriteFile(existing, []byte("1.0.1\n"), 0644)
+	os.WriteFile(created, []byte("# 1.0.1\n"), 0644)
+
+	if err := rb.run(true); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(existing)
+	if string(content) != "1.0.0\n" {
+		t.Errorf(".version = %q, want the original content", content)
+	}
+	if info, _ := os.Stat(existing); info.Mode().Perm() != 0600 {
+		t.Errorf(".version mode = %v, want 0600", info.Mode().Perm())
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Error("a file created by the release should be removed")
+	}
+}
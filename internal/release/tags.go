@@ -0,0 +1,64 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tagState tells where the tag of a release already exists, and which commit
+// it points at there.
+type tagState struct {
+	tag          string
+	remoteName   string
+	localObject  string
+	localCommit  string
+	local        bool
+	remoteObject string
+	remoteCommit string
+	remote       bool
+}
+
+// inspectTag looks for tagName locally and, unless remote is empty, on remote.
+func (o *Orchestrator) inspectTag(tagName, remote string) (*tagState, error) {
+	state := &tagState{tag: tagName, remoteName: remote}
+	state.localObject, state.localCommit, state.local = o.gitCmd.LocalTag(tagName)
+
+	if remote != "" {
+		object, commit, ok, err := o.gitCmd.RemoteTag(remote, tagName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up tag %s on %s: %w", tagName, remote, err)
+		}
+		state.remoteObject, state.remoteCommit, state.remote = object, commit, ok
+	}
+
+	return state, nil
+}
+
+func (s *tagState) exists() bool {
+	return s.local || s.remote
+}
+
+// describe explains the conflict, e.g. "tag v1.2.0 already exists locally at
+// 1a2b3c4d5e6f and on origin at 9f8e7d6c5b4a, a different commit".
+func (s *tagState) describe(head string) string {
+	at := func(commit string) string {
+		if commit == head {
+			return "at HEAD"
+		}
+		return "at " + shortCommit(commit)
+	}
+
+	var where []string
+	switch {
+	case s.local && s.remote && s.localCommit == s.remoteCommit:
+		where = append(where, "locally and on "+s.remoteName+", both "+at(s.localCommit))
+	case s.local && s.remote:
+		where = append(where, "locally "+at(s.localCommit), "on "+s.remoteName+" "+at(s.remoteCommit)+", a different commit")
+	case s.local:
+		where = append(where, "locally "+at(s.localCommit))
+	case s.remote:
+		where = append(where, "on "+s.remoteName+" "+at(s.remoteCommit))
+	}
+
+	return fmt.Sprintf("tag %s already exists %s", s.tag, strings.Join(where, " and "))
+}
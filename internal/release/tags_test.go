@@ -0,0 +1,43 @@
+package release
+
+import "testing"
+
+func TestTagState_Describe(t *testing.T) {
+	head := "aaaaaaaaaaaaaaaaaaaa"
+	other := "bbbbbbbbbbbbbbbbbbbb"
+
+	tests := []struct {
+		name  string
+		state tagState
+		want  string
+	}{
+		{
+			name:  "local only",
+			state: tagState{tag: "v1.2.0", remoteName: "origin", local: true, localCommit: other},
+			want:  "tag v1.2.0 already exists locally at bbbbbbbbbbbb",
+		},
+		{
+			name:  "remote only",
+			state: tagState{tag: "v1.2.0", remoteName: "origin", remote: true, remoteCommit: head},
+			want:  "tag v1.2.0 already exists on origin at HEAD",
+		},
+		{
+			name:  "same commit",
+			state: tagState{tag: "v1.2.0", remoteName: "origin", local: true, localCommit: other, remote: true, remoteCommit: other},
+			want:  "tag v1.2.0 already exists locally and on origin, both at bbbbbbbbbbbb",
+		},
+		{
+			name:  "different commits",
+			state: tagState{tag: "v1.2.0", remoteName: "origin", local: true, localCommit: head, remote: true, remoteCommit: other},
+			want:  "tag v1.2.0 already exists locally at HEAD and on origin at bbbbbbbbbbbb, a different commit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.state.describe(head); got != tt.want {
+				t.Errorf("describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ComposerSource edits the top-level "version" of composer.json in place,
+// unlike package.json which is re-encoded.
+type ComposerSource struct {
+	*StructuredSource
+}
+
+func NewComposerSource() VersionSource {
+	source, _ := NewStructuredSource("composer.json", "version")
+	return &ComposerSource{StructuredSource: source.(*StructuredSource)}
+}
+
+func (c *ComposerSource) Name() string {
+	return "composer.json"
+}
+
+func (c *ComposerSource) Detect(projectPath string) bool {
+	// The version field is optional in composer.json and usually omitted
+	content, err := os.ReadFile(filepath.Join(projectPath, "composer.json"))
+	if err != nil {
+		return false
+	}
+	_, err = c.locate(content)
+	return err == nil
+}
@@ -0,0 +1,111 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// CondaRecipeSource edits conda-build recipes as text, since meta.yaml is a
+// Jinja template and usually not valid YAML before rendering.
+type CondaRecipeSource struct{}
+
+func NewCondaRecipeSource() VersionSource {
+	return &CondaRecipeSource{}
+}
+
+var condaRecipeDirs = []string{"recipe", "conda.recipe", "conda", "."}
+
+// A Jinja variable is preferred, since "version: {{ version }}" then refers to it
+var condaVersionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^(\s*\{%-?\s*set\s+version\s*=\s*["'])([^"']+)(["'])`),
+	regexp.MustCompile(`(?m)^(\s+version:\s*["']?)([^"'\s{}#]+)(["']?[ \t]*(?:#.*)?)$`),
+}
+
+// The build number starts over with every new version
+var condaBuildNumberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^(\s*\{%-?\s*set\s+build(?:_number)?\s*=\s*["']?)(\d+)`),
+	regexp.MustCompile(`(?m)^(\s+number:\s*["']?)(\d+)`),
+}
+
+func (c *CondaRecipeSource) Name() string {
+	return "meta.yaml (conda recipe)"
+}
+
+func (c *CondaRecipeSource) GetDefaultFileName() string {
+	return "meta.yaml"
+}
+
+func (c *CondaRecipeSource) Detect(projectPath string) bool {
+	_, err := c.Locate(projectPath)
+	return err == nil
+}
+
+func (c *CondaRecipeSource) Locate(projectPath string) (string, error) {
+	for _, dir := range condaRecipeDirs {
+		path := filepath.Join(projectPath, dir, "meta.yaml")
+		if _, err := c.GetVersion(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no conda recipe with a version found")
+}
+
+func (c *CondaRecipeSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	for _, re := range condaVersionPatterns {
+		if matches := re.FindSubmatch(content); matches != nil {
+			return string(matches[2]), nil
+		}
+	}
+
+	return "", fmt.Errorf("version not found in %s", filepath.Base(filePath))
+}
+
+func (c *CondaRecipeSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	contentStr := string(content)
+	replaced := false
+	for _, re := range condaVersionPatterns {
+		if re.MatchString(contentStr) {
+			contentStr = replaceFirst(re, contentStr, "${1}"+newVersion+"${3}")
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		return fmt.Errorf("could not find version pattern to replace")
+	}
+
+	for _, re := range condaBuildNumberPatterns {
+		if re.MatchString(contentStr) {
+			contentStr = replaceFirst(re, contentStr, "${1}0")
+			break
+		}
+	}
+
+	if err := os.WriteFile(filePath, []byte(contentStr), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func (c *CondaRecipeSource) Advise(filePath, currentVersion, newVersion string) []string {
+	content, err := os.ReadFile(filePath)
+	if err != nil || !regexp.MustCompile(`(?m)^\s+sha256:`).Match(content) {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("%s pins a source sha256; update it once the %s archive is published", filepath.Base(filePath), newVersion),
+	}
+}
@@ -0,0 +1,27 @@
+package sources
+
+import (
+	"fmt"
+
+	"github.com/oriol/bumpr/internal/config"
+	"github.com/oriol/bumpr/internal/external"
+)
+
+// NewFromConfig builds a source declared in the sources section of the config file.
+func NewFromConfig(sc config.SourceConfig, runner external.CommandRunner, git external.GitBackend, tagPrefix string) (VersionSource, error) {
+	switch sc.Type {
+	case "pattern":
+		if sc.Search != "" {
+			return NewTemplatePatternSource(sc.File, sc.Search, sc.Replace)
+		}
+		return NewPatternSource(sc.File, sc.Pattern)
+	case "path":
+		return NewStructuredSource(sc.File, sc.Path)
+	case "git-tag":
+		return NewGitTagSource(git, tagPrefix), nil
+	case "plugin":
+		return NewPluginSource(runner, sc.Command, sc.File), nil
+	default:
+		return nil, fmt.Errorf("unknown source type %q", sc.Type)
+	}
+}
@@ -0,0 +1,121 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DebianChangelogSource reads the upstream version from the newest stanza of
+// debian/changelog and releases by prepending a new stanza, the way dch does.
+type DebianChangelogSource struct {
+	changes []string
+	now     func() time.Time
+}
+
+func NewDebianChangelogSource() VersionSource {
+	return &DebianChangelogSource{now: time.Now}
+}
+
+var (
+	debianHeader  = regexp.MustCompile(`(?m)^(\S+) \(([^)]+)\) ([^;]+);\s*(.*)$`)
+	debianTrailer = regexp.MustCompile(`(?m)^ -- (.+?)  (.+)$`)
+	debianVersion = regexp.MustCompile(`^(\d+:)?(.+?)(?:-([^-]+))?$`)
+)
+
+func (d *DebianChangelogSource) Name() string {
+	return "debian/changelog"
+}
+
+func (d *DebianChangelogSource) GetDefaultFileName() string {
+	return "debian/changelog"
+}
+
+func (d *DebianChangelogSource) Detect(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, "debian", "changelog"))
+	return err == nil
+}
+
+func (d *DebianChangelogSource) SetChanges(changes []string) {
+	d.changes = changes
+}
+
+func (d *DebianChangelogSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	header := debianHeader.FindSubmatch(content)
+	if header == nil {
+		return "", fmt.Errorf("no changelog stanza found in %s", filepath.Base(filePath))
+	}
+
+	// Only the upstream part is versioned by bumpr, not the epoch or Debian revision
+	parts := debianVersion.FindStringSubmatch(string(header[2]))
+	return parts[2], nil
+}
+
+func (d *DebianChangelogSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	header := debianHeader.FindSubmatch(content)
+	trailer := debianTrailer.FindSubmatch(content)
+	if header == nil || trailer == nil {
+		return fmt.Errorf("could not parse the latest changelog stanza")
+	}
+
+	parts := debianVersion.FindStringSubmatch(string(header[2]))
+	fullVersion := parts[1] + newVersion
+	if parts[3] != "" {
+		// A new upstream version restarts the Debian revision
+		fullVersion += "-1"
+	}
+
+	maintainer := debianMaintainer()
+	if maintainer == "" {
+		maintainer = string(trailer[1])
+	}
+
+	changes := d.changes
+	if len(changes) == 0 {
+		changes = []string{fmt.Sprintf("New upstream release %s.", newVersion)}
+	}
+
+	var stanza strings.Builder
+	fmt.Fprintf(&stanza, "%s (%s) %s; %s\n\n", header[1], fullVersion, header[3], header[4])
+	for _, change := range changes {
+		fmt.Fprintf(&stanza, "  * %s\n", change)
+	}
+	fmt.Fprintf(&stanza, "\n -- %s  %s\n\n", maintainer, d.now().Format(time.RFC1123Z))
+
+	if err := os.WriteFile(filePath, append([]byte(stanza.String()), content...), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// debianMaintainer follows dch: DEBFULLNAME and DEBEMAIL, where DEBEMAIL may
+// also hold the full "Name <email>" form.
+func debianMaintainer() string {
+	name := os.Getenv("DEBFULLNAME")
+	email := os.Getenv("DEBEMAIL")
+
+	switch {
+	case email == "":
+		return ""
+	case strings.Contains(email, "<"):
+		return email
+	case name != "":
+		return fmt.Sprintf("%s <%s>", name, email)
+	default:
+		return ""
+	}
+}
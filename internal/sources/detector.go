@@ -4,32 +4,182 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/oriol/bumpr/internal/external"
 )
 
 type Detector struct {
-	sources []VersionSource
+	sources    []VersionSource
+	fallbacks  []VersionSource
+	registered int
+	appended   int
+	priority   []string
+}
+
+// Candidate is a version source found in a project, together with the
+// version read from it.
+type Candidate struct {
+	Source  VersionSource
+	File    string
+	Version string
+	Err     error
+
+	// Set when the config file declares or prioritises this source
+	explicit bool
 }
 
 func NewDetector() *Detector {
 	return &Detector{
 		sources: []VersionSource{
 			NewPyProjectSource(),
+			NewVSCodeExtensionSource(),
 			NewPackageJsonSource(),
+			NewBrowserManifestSource(),
 			NewGalaxySource(),
+			NewAnsibleRoleSource(),
 			NewVersionFileSource(),
+			NewGoSource(),
+			NewDotNetSource(),
+			NewRubyGemSource(),
+			NewComposerSource(),
+			NewPubspecSource(),
+			NewMixSource(),
+			NewDebianChangelogSource(),
+			NewRPMSpecSource(),
+			NewCondaRecipeSource(),
+			NewNixSource(),
 		},
 	}
 }
 
+// Register adds a source ahead of the built-in ones, so explicitly configured
+// sources win during auto-detection. Registered sources keep their order.
+func (d *Detector) Register(source VersionSource) {
+	d.sources = append(d.sources[:d.registered], append([]VersionSource{source}, d.sources[d.registered:]...)...)
+	d.registered++
+}
+
+// Append adds a source after all others, e.g. plugins discovered on PATH.
+func (d *Detector) Append(source VersionSource) {
+	d.sources = append(d.sources, source)
+	d.appended++
+}
+
+// SetGit passes the git backend on to every source that needs one.
+func (d *Detector) SetGit(git external.GitBackend) {
+	for _, source := range append(d.sources, d.fallbacks...) {
+		if gitSource, ok := source.(GitSource); ok {
+			gitSource.SetGit(git)
+		}
+	}
+}
+
+// RegisterFallback adds a source that is only considered when no other
+// source is found.
+func (d *Detector) RegisterFallback(source VersionSource) {
+	d.fallbacks = append(d.fallbacks, source)
+}
+
+// SetPriority orders detected sources. Entries match a source name, its
+// default file name or the detected file's path relative to the project.
+// Sources declared in the config file always come first.
+func (d *Detector) SetPriority(priority []string) {
+	d.priority = priority
+}
+
+// DetectAll returns every source found in the project, ordered by priority.
+// Fallback sources are only tried when nothing else is found.
+func (d *Detector) DetectAll(projectPath string) []Candidate {
+	candidates := d.detect(d.sources, projectPath)
+	if len(candidates) == 0 {
+		candidates = d.detect(d.fallbacks, projectPath)
+	}
+	return d.order(candidates, projectPath)
+}
+
+// Resolve detects only as many sources as it takes to decide the one a
+// release uses, ordered by priority like DetectAll. A readable configured
+// source or a readable match of the first priority entry ends detection, and
+// appended sources (plugins) are only tried when no other source is readable,
+// unless the priority names them.
+func (d *Detector) Resolve(projectPath string) []Candidate {
+	var candidates []Candidate
+	for _, source := range d.sources[:d.registered] {
+		candidates = append(candidates, d.detect([]VersionSource{source}, projectPath)...)
+		if readable(candidates) {
+			return d.order(candidates, projectPath)
+		}
+	}
+
+	for _, source := range d.sources[d.registered : len(d.sources)-d.appended] {
+		for _, candidate := range d.detect([]VersionSource{source}, projectPath) {
+			candidates = append(candidates, candidate)
+			if candidate.Err == nil && len(d.priority) > 0 && d.rank(candidate, projectPath) == d.registered {
+				return d.order(candidates, projectPath)
+			}
+		}
+	}
+
+	for _, source := range d.sources[len(d.sources)-d.appended:] {
+		if !readable(candidates) || d.prioritised(source) {
+			candidates = append(candidates, d.detect([]VersionSource{source}, projectPath)...)
+		}
+	}
+
+	if len(candidates) == 0 {
+		candidates = d.detect(d.fallbacks, projectPath)
+	}
+	return d.order(candidates, projectPath)
+}
+
 func (d *Detector) DetectSource(projectPath string) (VersionSource, string, error) {
-	for _, source := range d.sources {
-		if source.Detect(projectPath) {
-			filePath := filepath.Join(projectPath, source.GetDefaultFileName())
-			return source, filePath, nil
+	candidates := d.Resolve(projectPath)
+	selected, err := d.Select(candidates)
+	if err != nil {
+		return nil, "", err
+	}
+	return selected.Source, selected.File, nil
+}
+
+// Select picks the candidate to release from. Candidates that agree on the
+// version are interchangeable and the first one wins; when they disagree a
+// configured priority decides, otherwise detection is ambiguous.
+func (d *Detector) Select(candidates []Candidate) (*Candidate, error) {
+	var readable []Candidate
+	for _, candidate := range candidates {
+		if candidate.Err == nil {
+			readable = append(readable, candidate)
+		}
+	}
+
+	if len(readable) == 0 {
+		if len(candidates) > 0 {
+			first := candidates[0]
+			return nil, fmt.Errorf("failed to read version from %s: %w", first.File, first.Err)
 		}
+		return nil, fmt.Errorf("no version source file found in project")
 	}
 
-	return nil, "", fmt.Errorf("no version source file found in project")
+	first := readable[0]
+	agree := true
+	for _, candidate := range readable[1:] {
+		if strings.TrimPrefix(candidate.Version, "v") != strings.TrimPrefix(first.Version, "v") {
+			agree = false
+			break
+		}
+	}
+
+	if agree || first.explicit {
+		return &first, nil
+	}
+
+	found := make([]string, len(readable))
+	for i, candidate := range readable {
+		found[i] = fmt.Sprintf("%s (%s)", candidate.Source.Name(), candidate.Version)
+	}
+	return nil, fmt.Errorf("version sources disagree: %s. Choose one with --source or set priority in the config file", strings.Join(found, ", "))
 }
 
 func (d *Detector) GetSourceByFile(filePath string) (VersionSource, error) {
@@ -40,7 +190,11 @@ func (d *Detector) GetSourceByFile(filePath string) (VersionSource, error) {
 	fileName := filepath.Base(filePath)
 	
 	for _, source := range d.sources {
-		if fileName == source.GetDefaultFileName() {
+		defaultName := source.GetDefaultFileName()
+		if fileName == defaultName || strings.HasSuffix(filepath.ToSlash(filePath), "/"+filepath.ToSlash(defaultName)) {
+			if matcher, ok := source.(FileMatcher); ok && !matcher.MatchesFile(filePath) {
+				continue
+			}
 			return source, nil
 		}
 	}
@@ -59,6 +213,15 @@ func (d *Detector) GetSourceByFile(filePath string) (VersionSource, error) {
 		if fileName == "galaxy.yml" || fileName == "galaxy.yaml" {
 			return NewGalaxySource(), nil
 		}
+		if filepath.Base(filepath.Dir(filePath)) == "meta" {
+			return NewAnsibleRoleSource(), nil
+		}
+	case ".go":
+		return NewGoSource(), nil
+	case ".csproj", ".fsproj", ".vbproj", ".props":
+		return NewDotNetSource(), nil
+	case ".spec":
+		return NewRPMSpecSource(), nil
 	}
 
 	// Default to version file for any other file
@@ -66,9 +229,85 @@ func (d *Detector) GetSourceByFile(filePath string) (VersionSource, error) {
 }
 
 func (d *Detector) ListAvailableSources() []string {
-	names := make([]string, len(d.sources))
-	for i, source := range d.sources {
-		names[i] = source.Name()
+	var names []string
+	for _, source := range append(d.sources, d.fallbacks...) {
+		names = append(names, source.Name())
 	}
 	return names
-}
\ No newline at end of file
+}
+
+func (d *Detector) detect(sources []VersionSource, projectPath string) []Candidate {
+	var candidates []Candidate
+	for _, source := range sources {
+		if !source.Detect(projectPath) {
+			continue
+		}
+
+		candidate := Candidate{Source: source}
+		candidate.File, candidate.Err = locateSourceFile(source, projectPath)
+		if candidate.Err == nil {
+			candidate.Version, candidate.Err = source.GetVersion(candidate.File)
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// order marks the explicitly chosen candidates and sorts them by rank.
+func (d *Detector) order(candidates []Candidate, projectPath string) []Candidate {
+	unranked := d.registered + len(d.priority)
+	for i := range candidates {
+		candidates[i].explicit = d.rank(candidates[i], projectPath) < unranked
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return d.rank(candidates[i], projectPath) < d.rank(candidates[j], projectPath)
+	})
+
+	return candidates
+}
+
+// prioritised reports whether a priority entry names source.
+func (d *Detector) prioritised(source VersionSource) bool {
+	for _, entry := range d.priority {
+		if entry == source.Name() || entry == source.GetDefaultFileName() {
+			return true
+		}
+	}
+	return false
+}
+
+func readable(candidates []Candidate) bool {
+	for _, candidate := range candidates {
+		if candidate.Err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// rank orders configured sources first, then the configured priority, then
+// registration order (kept by the stable sort).
+func (d *Detector) rank(candidate Candidate, projectPath string) int {
+	for i, source := range d.sources[:d.registered] {
+		if source == candidate.Source {
+			return i
+		}
+	}
+
+	relPath, _ := filepath.Rel(projectPath, candidate.File)
+	for i, entry := range d.priority {
+		if entry == candidate.Source.Name() || entry == candidate.Source.GetDefaultFileName() || entry == filepath.ToSlash(relPath) {
+			return d.registered + i
+		}
+	}
+
+	return d.registered + len(d.priority)
+}
+
+func locateSourceFile(source VersionSource, projectPath string) (string, error) {
+	if locator, ok := source.(Locator); ok {
+		return locator.Locate(projectPath)
+	}
+	return filepath.Join(projectPath, source.GetDefaultFileName()), nil
+}
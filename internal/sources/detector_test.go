@@ -0,0 +1,226 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeProjectFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestDetector_DetectSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		priority []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name: "agreeing sources pick the first registered",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nversion = \"1.2.0\"\n",
+				"package.json":   `{"version": "1.2.0"}`,
+			},
+			want: "pyproject.toml",
+		},
+		{
+			name: "v prefix does not count as disagreement",
+			files: map[string]string{
+				"package.json": `{"version": "1.2.0"}`,
+				".version":     "v1.2.0\n",
+			},
+			want: "package.json",
+		},
+		{
+			name: "disagreeing sources are ambiguous",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nversion = \"1.2.0\"\n",
+				"package.json":   `{"version": "1.3.0"}`,
+			},
+			wantErr: "pyproject.toml (1.2.0), package.json (1.3.0)",
+		},
+		{
+			name: "priority resolves disagreement",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nversion = \"1.2.0\"\n",
+				"package.json":   `{"version": "1.3.0"}`,
+			},
+			priority: []string{"package.json"},
+			want:     "package.json",
+		},
+		{
+			name: "priority that matches nothing does not hide disagreement",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nversion = \"1.2.0\"\n",
+				"package.json":   `{"version": "1.3.0"}`,
+			},
+			priority: []string{"galaxy.yml"},
+			wantErr:  "disagree",
+		},
+		{
+			name:    "nothing found",
+			files:   map[string]string{"README.md": "hello"},
+			wantErr: "no version source",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeProjectFiles(t, tt.files)
+
+			d := NewDetector()
+			d.SetPriority(tt.priority)
+
+			_, file, err := d.DetectSource(dir)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DetectSource() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DetectSource() error = %v", err)
+			}
+			if want := filepath.Join(dir, tt.want); file != want {
+				t.Errorf("DetectSource() file = %v, want %v", file, want)
+			}
+		})
+	}
+}
+
+func TestDetector_RegisteredSourcesWin(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json": `{"version": "1.3.0"}`,
+		"Dockerfile":   "LABEL version=\"0.1.0\"\n",
+	})
+
+	pattern, err := NewPatternSource("Dockerfile", `version="(?P<version>[^"]+)"`)
+	if err != nil {
+		t.Fatalf("NewPatternSource() error = %v", err)
+	}
+
+	d := NewDetector()
+	d.Register(pattern)
+
+	candidates := d.DetectAll(dir)
+	if len(candidates) != 2 {
+		t.Fatalf("DetectAll() returned %d candidates, want 2", len(candidates))
+	}
+
+	source, _, err := d.DetectSource(dir)
+	if err != nil {
+		t.Fatalf("DetectSource() error = %v", err)
+	}
+	if source != pattern {
+		t.Errorf("DetectSource() = %v, want the registered pattern source", source.Name())
+	}
+}
+
+// countingSource records how often it is probed.
+type countingSource struct {
+	name   string
+	probes int
+}
+
+func (s *countingSource) Name() string               { return s.name }
+func (s *countingSource) GetDefaultFileName() string { return s.name }
+func (s *countingSource) Detect(string) bool {
+	s.probes++
+	return true
+}
+func (s *countingSource) GetVersion(string) (string, error) { return "9.9.9", nil }
+func (s *countingSource) SetVersion(string, string) error   { return nil }
+
+func TestDetector_ResolveStopsEarly(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		priority   []string
+		wantProbes int
+		want       string
+	}{
+		{
+			name:       "plugins are skipped when a built-in source is readable",
+			files:      map[string]string{"package.json": `{"version": "1.2.0"}`},
+			wantProbes: 0,
+			want:       "package.json",
+		},
+		{
+			name:       "plugins are probed when no built-in source matches",
+			files:      map[string]string{"README.md": "hello"},
+			wantProbes: 1,
+			want:       "plugin",
+		},
+		{
+			name:       "prioritised plugins are probed",
+			files:      map[string]string{"package.json": `{"version": "1.2.0"}`},
+			priority:   []string{"plugin"},
+			wantProbes: 1,
+			want:       "plugin",
+		},
+		{
+			name:       "first priority entry ends detection",
+			files:      map[string]string{"package.json": `{"version": "1.2.0"}`},
+			priority:   []string{"package.json"},
+			wantProbes: 0,
+			want:       "package.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeProjectFiles(t, tt.files)
+
+			plugin := &countingSource{name: "plugin"}
+			d := NewDetector()
+			d.Append(plugin)
+			d.SetPriority(tt.priority)
+
+			source, _, err := d.DetectSource(dir)
+			if err != nil {
+				t.Fatalf("DetectSource() error = %v", err)
+			}
+			if plugin.probes != tt.wantProbes {
+				t.Errorf("plugin probed %d times, want %d", plugin.probes, tt.wantProbes)
+			}
+			if got := source.GetDefaultFileName(); got != tt.want {
+				t.Errorf("DetectSource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetector_ResolveStopsAtRegisteredSource(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{"package.json": `{"version": "1.3.0"}`})
+
+	configured := &countingSource{name: "configured"}
+	plugin := &countingSource{name: "plugin"}
+
+	d := NewDetector()
+	d.Register(configured)
+	d.Append(plugin)
+
+	candidates := d.Resolve(dir)
+	if len(candidates) != 1 || candidates[0].Source != configured {
+		t.Fatalf("Resolve() returned %d candidates, want only the configured source", len(candidates))
+	}
+	if plugin.probes != 0 {
+		t.Errorf("plugin probed %d times, want 0", plugin.probes)
+	}
+}
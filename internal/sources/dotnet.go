@@ -0,0 +1,191 @@
+package sources
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type DotNetSource struct{}
+
+func NewDotNetSource() VersionSource {
+	return &DotNetSource{}
+}
+
+func (d *DotNetSource) Name() string {
+	return "Directory.Build.props/*.csproj"
+}
+
+func (d *DotNetSource) GetDefaultFileName() string {
+	return "Directory.Build.props"
+}
+
+func (d *DotNetSource) Detect(projectPath string) bool {
+	_, err := d.Locate(projectPath)
+	return err == nil
+}
+
+func (d *DotNetSource) Locate(projectPath string) (string, error) {
+	// Directory.Build.props applies to every project below it, so it wins
+	props := filepath.Join(projectPath, d.GetDefaultFileName())
+	if d.hasVersionElement(props) {
+		return props, nil
+	}
+
+	found := ""
+	err := filepath.WalkDir(projectPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != projectPath && (strings.HasPrefix(name, ".") || name == "bin" || name == "obj" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			// Projects live at most in src/<Name>/, no need to walk whole trees
+			if relPath, _ := filepath.Rel(projectPath, path); strings.Count(filepath.ToSlash(relPath), "/") >= 2 {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ext := filepath.Ext(path)
+		if ext != ".csproj" && ext != ".fsproj" && ext != ".vbproj" {
+			return nil
+		}
+
+		if d.hasVersionElement(path) {
+			found = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to search for project files: %w", err)
+	}
+
+	if found == "" {
+		return "", fmt.Errorf("no MSBuild project with a version element found")
+	}
+
+	return found, nil
+}
+
+func (d *DotNetSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Versions computed from other properties, e.g. $(VersionPrefix), are skipped
+	if version, ok := msbuildProperty(content, "Version"); ok && !strings.Contains(version, "$(") {
+		return version, nil
+	}
+
+	if prefix, ok := msbuildProperty(content, "VersionPrefix"); ok {
+		if suffix, ok := msbuildProperty(content, "VersionSuffix"); ok && suffix != "" {
+			return prefix + "-" + suffix, nil
+		}
+		return prefix, nil
+	}
+
+	return "", fmt.Errorf("no <Version> or <VersionPrefix> found in %s", filepath.Base(filePath))
+}
+
+func (d *DotNetSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// MSBuild versions never carry the v prefix
+	bare := strings.TrimPrefix(newVersion, "v")
+	prefix, suffix := bare, ""
+	if idx := strings.IndexAny(bare, "-+"); idx >= 0 {
+		prefix, suffix = bare[:idx], strings.TrimPrefix(bare[idx:], "-")
+	}
+	core := strings.Split(prefix, ".")
+
+	contentStr := string(content)
+	replaced := false
+
+	update := func(element string, value func(current string) string) {
+		re := msbuildElement(element)
+		contentStr = re.ReplaceAllStringFunc(contentStr, func(match string) string {
+			parts := re.FindStringSubmatch(match)
+			if strings.Contains(parts[2], "$(") {
+				return match
+			}
+			replaced = true
+			return parts[1] + value(parts[2]) + parts[3]
+		})
+	}
+
+	update("Version", func(string) string { return bare })
+	update("VersionPrefix", func(string) string { return prefix })
+	update("VersionSuffix", func(string) string { return suffix })
+	update("PackageVersion", func(string) string { return bare })
+	update("InformationalVersion", func(string) string { return bare })
+	update("AssemblyVersion", func(current string) string { return numericVersion(current, core) })
+	update("FileVersion", func(current string) string { return numericVersion(current, core) })
+
+	if !replaced {
+		return fmt.Errorf("could not find version elements to replace")
+	}
+
+	if err := os.WriteFile(filePath, []byte(contentStr), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DotNetSource) hasVersionElement(filePath string) bool {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return false
+	}
+
+	_, hasVersion := msbuildProperty(content, "Version")
+	_, hasPrefix := msbuildProperty(content, "VersionPrefix")
+	return hasVersion || hasPrefix
+}
+
+func msbuildElement(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(<` + name + `(?:\s[^>]*)?>\s*)([^<]*?)(\s*</` + name + `>)`)
+}
+
+func msbuildProperty(content []byte, name string) (string, bool) {
+	matches := msbuildElement(name).FindSubmatch(content)
+	if matches == nil {
+		return "", false
+	}
+	return string(matches[2]), true
+}
+
+// numericVersion renders the new major.minor.patch in the shape of an existing
+// assembly or file version: the number of parts is kept, wildcards stay in
+// place and a fourth (revision) part is reset to 0.
+func numericVersion(current string, core []string) string {
+	parts := strings.Split(current, ".")
+	if current == "" || len(parts) > 4 {
+		parts = []string{"0", "0", "0", "0"}
+	}
+
+	for i := range parts {
+		if parts[i] == "*" {
+			continue
+		}
+		if i < len(core) {
+			parts[i] = core[i]
+		} else {
+			parts[i] = "0"
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
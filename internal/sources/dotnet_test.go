@@ -0,0 +1,155 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDotNetSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		want       string
+		newVersion string
+		wantAfter  string
+	}{
+		{
+			name: "version with assembly and file versions",
+			content: `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+    <Version>1.2.3</Version>
+    <AssemblyVersion>1.2.3.0</AssemblyVersion>
+    <FileVersion>1.2.3.45</FileVersion>
+  </PropertyGroup>
+</Project>
+`,
+			want:       "1.2.3",
+			newVersion: "1.3.0",
+			wantAfter: `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+    <Version>1.3.0</Version>
+    <AssemblyVersion>1.3.0.0</AssemblyVersion>
+    <FileVersion>1.3.0.0</FileVersion>
+  </PropertyGroup>
+</Project>
+`,
+		},
+		{
+			name: "prefix and suffix with computed version",
+			content: `<Project>
+  <PropertyGroup>
+    <VersionPrefix>2.0.0</VersionPrefix>
+    <VersionSuffix></VersionSuffix>
+    <Version>$(VersionPrefix)</Version>
+    <AssemblyVersion>2.0.*</AssemblyVersion>
+  </PropertyGroup>
+</Project>
+`,
+			want:       "2.0.0",
+			newVersion: "v3.0.0",
+			wantAfter: `<Project>
+  <PropertyGroup>
+    <VersionPrefix>3.0.0</VersionPrefix>
+    <VersionSuffix></VersionSuffix>
+    <Version>$(VersionPrefix)</Version>
+    <AssemblyVersion>3.0.*</AssemblyVersion>
+  </PropertyGroup>
+</Project>
+`,
+		},
+		{
+			name: "conditional element with whitespace",
+			content: `<Project>
+  <PropertyGroup>
+    <Version Condition="'$(Version)' == ''">
+      0.9.0
+    </Version>
+  </PropertyGroup>
+</Project>
+`,
+			want:       "0.9.0",
+			newVersion: "0.9.1",
+			wantAfter: `<Project>
+  <PropertyGroup>
+    <Version Condition="'$(Version)' == ''">
+      0.9.1
+    </Version>
+  </PropertyGroup>
+</Project>
+`,
+		},
+	}
+
+	d := NewDotNetSource()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(t.TempDir(), "Lib.csproj")
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create temp file: %v", err)
+			}
+
+			got, err := d.GetVersion(tmpFile)
+			if err != nil {
+				t.Fatalf("GetVersion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetVersion() = %v, want %v", got, tt.want)
+			}
+
+			if err := d.SetVersion(tmpFile, tt.newVersion); err != nil {
+				t.Fatalf("SetVersion() error = %v", err)
+			}
+
+			content, err := os.ReadFile(tmpFile)
+			if err != nil {
+				t.Fatalf("failed to read updated file: %v", err)
+			}
+			if string(content) != tt.wantAfter {
+				t.Errorf("SetVersion() content =\n%s\nwant:\n%s", content, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestDotNetSource_Locate(t *testing.T) {
+	d := NewDotNetSource()
+
+	tmpDir := t.TempDir()
+	project := filepath.Join(tmpDir, "src", "Lib", "Lib.csproj")
+	if err := os.MkdirAll(filepath.Dir(project), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(project, []byte("<Project><PropertyGroup><Version>1.0.0</Version></PropertyGroup></Project>"), 0644); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	// Directory.Build.props without a version is ignored
+	props := filepath.Join(tmpDir, "Directory.Build.props")
+	if err := os.WriteFile(props, []byte("<Project><PropertyGroup><Nullable>enable</Nullable></PropertyGroup></Project>"), 0644); err != nil {
+		t.Fatalf("failed to create props: %v", err)
+	}
+
+	got, err := d.(Locator).Locate(tmpDir)
+	if err != nil {
+		t.Fatalf("Locate() error = %v", err)
+	}
+	if got != project {
+		t.Errorf("Locate() = %v, want %v", got, project)
+	}
+
+	if err := os.WriteFile(props, []byte("<Project><PropertyGroup><VersionPrefix>1.0.0</VersionPrefix></PropertyGroup></Project>"), 0644); err != nil {
+		t.Fatalf("failed to update props: %v", err)
+	}
+
+	got, err = d.(Locator).Locate(tmpDir)
+	if err != nil {
+		t.Fatalf("Locate() error = %v", err)
+	}
+	if got != props {
+		t.Errorf("Locate() = %v, want %v", got, props)
+	}
+}
@@ -0,0 +1,241 @@
+package sources
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Browsers only accept 1-4 dot-separated integers of at most 65535
+const maxManifestVersionPart = 65535
+
+// BrowserManifestSource handles the manifest.json of Chrome, Firefox and
+// other WebExtensions. SemVer pre-releases do not fit the numeric "version"
+// field, so they are written to "version_name" while "version" gets the
+// numeric core. Values are edited in place to keep the formatting.
+type BrowserManifestSource struct{}
+
+func NewBrowserManifestSource() VersionSource {
+	return &BrowserManifestSource{}
+}
+
+func (b *BrowserManifestSource) Name() string {
+	return "manifest.json (browser extension)"
+}
+
+func (b *BrowserManifestSource) GetDefaultFileName() string {
+	return "manifest.json"
+}
+
+func (b *BrowserManifestSource) Detect(projectPath string) bool {
+	_, err := b.Locate(projectPath)
+	return err == nil
+}
+
+func (b *BrowserManifestSource) Locate(projectPath string) (string, error) {
+	for _, dir := range []string{".", "src", "extension", "public"} {
+		path := filepath.Join(projectPath, dir, "manifest.json")
+		if b.MatchesFile(path) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no browser extension manifest.json found")
+}
+
+// MatchesFile tells extension manifests apart from other manifest.json
+// files, such as web app manifests, by their manifest_version key.
+func (b *BrowserManifestSource) MatchesFile(filePath string) bool {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return false
+	}
+
+	var manifest struct {
+		ManifestVersion json.Number `json:"manifest_version"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return false
+	}
+	return manifest.ManifestVersion != ""
+}
+
+func (b *BrowserManifestSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	span, err := locateJSONValue(content, []string{"version"})
+	if err != nil {
+		return "", err
+	}
+	if span == nil {
+		return "", fmt.Errorf("version field not found in %s", filepath.Base(filePath))
+	}
+
+	// A pre-release is only visible in version_name
+	if nameSpan, _ := locateJSONValue(content, []string{"version_name"}); nameSpan != nil {
+		core, _, _ := strings.Cut(nameSpan.value, "-")
+		if strings.Contains(nameSpan.value, "-") && strings.HasPrefix(span.value, core) {
+			return nameSpan.value, nil
+		}
+	}
+
+	// 1.2 reads as 1.2.0, and a fourth (build) part is not part of the release version
+	parts := strings.Split(span.value, ".")
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	return strings.Join(parts[:3], "."), nil
+}
+
+func (b *BrowserManifestSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	bare := strings.TrimPrefix(newVersion, "v")
+	prefix := bare
+	if idx := strings.IndexAny(bare, "-+"); idx >= 0 {
+		prefix = bare[:idx]
+	}
+
+	span, err := locateJSONValue(content, []string{"version"})
+	if err != nil {
+		return err
+	}
+	if span == nil {
+		return fmt.Errorf("version field not found in %s", filepath.Base(filePath))
+	}
+
+	// Keep a four part version in shape, resetting the build part
+	numeric := prefix
+	if strings.Count(span.value, ".") == 3 {
+		numeric = numericVersion(span.value, strings.Split(prefix, "."))
+	}
+
+	output := spliceJSONString(content, span, numeric)
+
+	nameSpan, err := locateJSONValue(output, []string{"version_name"})
+	if err != nil {
+		return err
+	}
+	switch {
+	case nameSpan != nil:
+		output = spliceJSONString(output, nameSpan, bare)
+	case bare != prefix:
+		// Add version_name right after version, on a line of its own if version has one
+		versionSpan, _ := locateJSONValue(output, []string{"version"})
+		line := output[bytes.LastIndexByte(output[:versionSpan.start], '\n')+1 : versionSpan.start]
+		indent := line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+		name, _ := json.Marshal(bare)
+
+		separator := ",\n" + string(indent)
+		if !bytes.HasPrefix(bytes.TrimLeft(line, " \t"), []byte(`"version"`)) {
+			separator = ", "
+		}
+		insert := separator + `"version_name": ` + string(name)
+		output = append(output[:versionSpan.end:versionSpan.end], append([]byte(insert), output[versionSpan.end:]...)...)
+	}
+
+	if err := os.WriteFile(filePath, output, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateVersion enforces the browser rules on the numeric part; any
+// pre-release or build suffix goes to version_name.
+func (b *BrowserManifestSource) ValidateVersion(newVersion string) error {
+	bare := strings.TrimPrefix(newVersion, "v")
+	if idx := strings.IndexAny(bare, "-+"); idx >= 0 {
+		bare = bare[:idx]
+	}
+
+	parts := strings.Split(bare, ".")
+	if len(parts) > 4 {
+		return fmt.Errorf("browser extension versions have at most 4 parts, got %s", bare)
+	}
+
+	allZero := true
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || n > maxManifestVersionPart {
+			return fmt.Errorf("browser extension version parts must be integers between 0 and %d, got %s", maxManifestVersionPart, bare)
+		}
+		if len(part) > 1 && part[0] == '0' {
+			return fmt.Errorf("browser extension version parts must not have leading zeros, got %s", bare)
+		}
+		if n != 0 {
+			allZero = false
+		}
+	}
+	if allZero {
+		return fmt.Errorf("browser extension version must not be all zeros")
+	}
+
+	return nil
+}
+
+func (b *BrowserManifestSource) Advise(filePath, currentVersion, newVersion string) []string {
+	bare := strings.TrimPrefix(newVersion, "v")
+	idx := strings.IndexAny(bare, "-+")
+	if idx < 0 {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("%s is published as version %s; extension stores require the next upload to use a higher version", newVersion, bare[:idx]),
+	}
+}
+
+// VSCodeExtensionSource handles the package.json of VS Code extensions,
+// recognised by engines.vscode. Unlike PackageJsonSource it edits the version
+// in place, and it rejects versions the Marketplace does not accept.
+type VSCodeExtensionSource struct {
+	*StructuredSource
+}
+
+func NewVSCodeExtensionSource() VersionSource {
+	source, _ := NewStructuredSource("package.json", "version")
+	return &VSCodeExtensionSource{StructuredSource: source.(*StructuredSource)}
+}
+
+func (v *VSCodeExtensionSource) Name() string {
+	return "package.json (VS Code extension)"
+}
+
+func (v *VSCodeExtensionSource) Detect(projectPath string) bool {
+	return v.MatchesFile(filepath.Join(projectPath, "package.json"))
+}
+
+func (v *VSCodeExtensionSource) MatchesFile(filePath string) bool {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return false
+	}
+	span, err := locateJSONValue(content, []string{"engines", "vscode"})
+	return err == nil && span != nil
+}
+
+// ValidateVersion rejects pre-release and build suffixes: the Marketplace
+// only takes major.minor.patch and marks pre-releases with a publish flag.
+func (v *VSCodeExtensionSource) ValidateVersion(newVersion string) error {
+	if strings.ContainsAny(newVersion, "-+") {
+		return fmt.Errorf("VS Code extensions only accept major.minor.patch versions, publish pre-releases with vsce publish --pre-release")
+	}
+	return nil
+}
+
+func spliceJSONString(content []byte, span *valueSpan, value string) []byte {
+	literal, _ := json.Marshal(value)
+	output := make([]byte, 0, len(content)+len(literal))
+	output = append(output, content[:span.start]...)
+	output = append(output, literal...)
+	return append(output, content[span.end:]...)
+}
@@ -0,0 +1,213 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBrowserManifestSource(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantVersion string
+		newVersion  string
+		want        string
+	}{
+		{
+			name: "three part version",
+			content: `{
+  "manifest_version": 3,
+  "name": "Demo",
+  "version": "1.2.3",
+  "action": {}
+}
+`,
+			wantVersion: "1.2.3",
+			newVersion:  "1.2.4",
+			want: `{
+  "manifest_version": 3,
+  "name": "Demo",
+  "version": "1.2.4",
+  "action": {}
+}
+`,
+		},
+		{
+			name:        "two part version is padded",
+			content:     `{"manifest_version": 2, "version": "1.2"}`,
+			wantVersion: "1.2.0",
+			newVersion:  "1.3.0",
+			want:        `{"manifest_version": 2, "version": "1.3.0"}`,
+		},
+		{
+			name:        "four part version keeps its shape",
+			content:     `{"manifest_version": 3, "version": "1.2.3.45"}`,
+			wantVersion: "1.2.3",
+			newVersion:  "1.2.4",
+			want:        `{"manifest_version": 3, "version": "1.2.4.0"}`,
+		},
+		{
+			name: "pre-release adds version_name",
+			content: `{
+	"manifest_version": 3,
+	"version": "1.2.3"
+}
+`,
+			wantVersion: "1.2.3",
+			newVersion:  "1.3.0-beta.1",
+			want: `{
+	"manifest_version": 3,
+	"version": "1.3.0",
+	"version_name": "1.3.0-beta.1"
+}
+`,
+		},
+		{
+			name:        "four part version with a pre-release",
+			content:     `{"manifest_version": 3, "version": "1.2.3.4", "version_name": "1.2.3"}`,
+			wantVersion: "1.2.3",
+			newVersion:  "1.3.0-rc.1",
+			want:        `{"manifest_version": 3, "version": "1.3.0.0", "version_name": "1.3.0-rc.1"}`,
+		},
+		{
+			name:        "build metadata goes to version_name",
+			content:     `{"manifest_version": 3, "version": "1.2.3"}`,
+			wantVersion: "1.2.3",
+			newVersion:  "1.2.4+build.7",
+			want:        `{"manifest_version": 3, "version": "1.2.4", "version_name": "1.2.4+build.7"}`,
+		},
+		{
+			name:        "existing version_name is updated",
+			content:     `{"manifest_version": 3, "version_name": "1.3.0-beta.1", "version": "1.3.0"}`,
+			wantVersion: "1.3.0-beta.1",
+			newVersion:  "1.3.0",
+			want:        `{"manifest_version": 3, "version_name": "1.3.0", "version": "1.3.0"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "manifest.json")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create manifest: %v", err)
+			}
+
+			source := NewBrowserManifestSource()
+			got, err := source.GetVersion(filePath)
+			if err != nil || got != tt.wantVersion {
+				t.Fatalf("GetVersion() = %q, %v, want %q", got, err, tt.wantVersion)
+			}
+
+			if err := source.SetVersion(filePath, tt.newVersion); err != nil {
+				t.Fatalf("SetVersion() error = %v", err)
+			}
+			written, _ := os.ReadFile(filePath)
+			if string(written) != tt.want {
+				t.Errorf("SetVersion() wrote:\n%s\nwant:\n%s", written, tt.want)
+			}
+		})
+	}
+}
+
+func TestBrowserManifestSource_ValidateVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		wantErr bool
+	}{
+		{version: "1.2.3"},
+		{version: "v2.0.0"},
+		{version: "1.2.3.4"},
+		{version: "1.3.0-beta.1"},
+		{version: "1.3.0+build.7"},
+		{version: "65536.0.0-beta.1", wantErr: true},
+		{version: "65535.0.0"},
+		{version: "65536.0.0", wantErr: true},
+		{version: "1.2.3.4.5", wantErr: true},
+		{version: "1.02.3", wantErr: true},
+		{version: "0.0.0", wantErr: true},
+	}
+
+	source := NewBrowserManifestSource().(VersionValidator)
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if err := source.ValidateVersion(tt.version); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBrowserManifestSource_IgnoresWebAppManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "manifest.json"), []byte(`{"name": "App", "version": "1.0.0"}`), 0644); err != nil {
+		t.Fatalf("failed to create manifest: %v", err)
+	}
+	if NewBrowserManifestSource().Detect(tmpDir) {
+		t.Error("Detect() = true for a web app manifest")
+	}
+
+	extensionDir := filepath.Join(tmpDir, "extension")
+	if err := os.MkdirAll(extensionDir, 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(extensionDir, "manifest.json"), []byte(`{"manifest_version": 3, "version": "1.0"}`), 0644); err != nil {
+		t.Fatalf("failed to create manifest: %v", err)
+	}
+
+	file, err := locateSourceFile(NewBrowserManifestSource(), tmpDir)
+	if err != nil || file != filepath.Join(extensionDir, "manifest.json") {
+		t.Errorf("Locate() = %q, %v, want the extension manifest", file, err)
+	}
+}
+
+func TestVSCodeExtensionSource(t *testing.T) {
+	content := `{
+    "name": "demo",
+    "displayName": "Demo",
+    "version": "0.4.1",
+    "engines": {
+        "vscode": "^1.80.0"
+    },
+    "contributes": {}
+}
+`
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "package.json")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create package.json: %v", err)
+	}
+
+	detector := NewDetector()
+	source, file, err := detector.DetectSource(tmpDir)
+	if err != nil {
+		t.Fatalf("DetectSource() error = %v", err)
+	}
+	if _, ok := source.(*VSCodeExtensionSource); !ok {
+		t.Fatalf("DetectSource() = %s, want the VS Code extension source", source.Name())
+	}
+	if bySource, _ := detector.GetSourceByFile(file); bySource != source {
+		t.Errorf("GetSourceByFile() = %v, want the VS Code extension source", bySource)
+	}
+
+	if err := source.(VersionValidator).ValidateVersion("0.5.0-rc.1"); err == nil {
+		t.Error("ValidateVersion() accepted a pre-release")
+	}
+
+	if err := source.SetVersion(file, "0.4.2"); err != nil {
+		t.Fatalf("SetVersion() error = %v", err)
+	}
+	written, _ := os.ReadFile(file)
+	if want := `    "version": "0.4.2",`; !contains(string(written), want) || len(written) != len(content) {
+		t.Errorf("SetVersion() wrote:\n%s", written)
+	}
+
+	// Plain Node packages are left to PackageJsonSource
+	plain := filepath.Join(t.TempDir(), "package.json")
+	if err := os.WriteFile(plain, []byte(`{"name": "lib", "version": "1.0.0"}`), 0644); err != nil {
+		t.Fatalf("failed to create package.json: %v", err)
+	}
+	if bySource, _ := detector.GetSourceByFile(plain); bySource.Name() != "package.json" {
+		t.Errorf("GetSourceByFile() = %s, want package.json", bySource.Name())
+	}
+}
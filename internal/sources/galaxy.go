@@ -6,7 +6,7 @@ import (
 	"path/filepath"
 	"regexp"
 
-	"gopkg.in/yaml.v3"
+	"github.com/oriol/bumpr/internal/version"
 )
 
 type GalaxySource struct{}
@@ -24,46 +24,50 @@ func (g *GalaxySource) GetDefaultFileName() string {
 }
 
 func (g *GalaxySource) Detect(projectPath string) bool {
-	_, err := os.Stat(filepath.Join(projectPath, "galaxy.yml"))
+	_, err := g.Locate(projectPath)
 	return err == nil
 }
 
+func (g *GalaxySource) Locate(projectPath string) (string, error) {
+	for _, name := range []string{"galaxy.yml", "galaxy.yaml"} {
+		filePath := filepath.Join(projectPath, name)
+		if _, err := os.Stat(filePath); err == nil {
+			return filePath, nil
+		}
+	}
+	return "", fmt.Errorf("no galaxy.yml or galaxy.yaml found")
+}
+
+// ValidateVersion enforces Galaxy's rule that collection versions are strict SemVer.
+func (g *GalaxySource) ValidateVersion(v string) error {
+	if err := version.ValidateStrictSemVer(v); err != nil {
+		return fmt.Errorf("Ansible Galaxy requires strict SemVer collection versions: %w", err)
+	}
+	return nil
+}
+
 func (g *GalaxySource) GetVersion(filePath string) (string, error) {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// First try simple regex match to handle quoted strings properly
-	re := regexp.MustCompile(`(?m)^version:\s*["']?([^"'\s]+)["']?\s*$`)
-	matches := re.FindSubmatch(content)
-	if matches != nil {
-		return string(matches[1]), nil
-	}
-
-	// If regex fails, try YAML parsing
-	var data map[string]interface{}
-	if err := yaml.Unmarshal(content, &data); err != nil {
-		return "", fmt.Errorf("failed to parse YAML: %w", err)
-	}
-
-	// Check for version field
-	if version, ok := data["version"]; ok {
-		switch v := version.(type) {
-		case string:
-			return v, nil
-		case float64:
-			// Handle cases where version might be parsed as float
-			return fmt.Sprintf("%.1f", v), nil
-		case int:
-			// Handle cases where version might be parsed as int
-			return fmt.Sprintf("%d.0.0", v), nil
-		default:
-			return "", fmt.Errorf("version field is not a string: %T", version)
+	// Read the same top-level key SetVersion writes, as written: an unquoted
+	// 1.10 stays 1.10
+	span, err := locateYAMLValue(content, []string{"version"})
+	if err != nil {
+		// Malformed YAML, e.g. an unterminated quote: look for the line itself
+		re := regexp.MustCompile(`(?m)^version:\s*["']?([^"'\s]+)["']?\s*$`)
+		if matches := re.FindSubmatch(content); matches != nil {
+			return string(matches[1]), nil
 		}
+		return "", err
+	}
+	if span == nil {
+		return "", fmt.Errorf("version field not found in %s", filepath.Base(filePath))
 	}
 
-	return "", fmt.Errorf("version field not found in galaxy.yml")
+	return span.value, nil
 }
 
 func (g *GalaxySource) SetVersion(filePath string, newVersion string) error {
@@ -72,26 +76,17 @@ func (g *GalaxySource) SetVersion(filePath string, newVersion string) error {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
-	contentStr := string(content)
-	
-	// Use regex to replace version while preserving formatting
-	// This handles various formats: version: "1.0.0", version: '1.0.0', version: 1.0.0
-	re := regexp.MustCompile(`(?m)^(\s*version:\s*)["']?[^"'\s]+["']?(\s*)$`)
-	
-	if !re.MatchString(contentStr) {
-		return fmt.Errorf("could not find version pattern to replace")
+	// Only the top-level key is the collection version; nested ones (e.g. in
+	// dependencies) must be left alone
+	span, err := locateYAMLValue(content, []string{"version"})
+	if err != nil {
+		return err
 	}
-
-	// Preserve quotes if they were present
-	versionPattern := regexp.MustCompile(`(?m)^version:\s*(["']?)`)
-	matches := versionPattern.FindStringSubmatch(contentStr)
-	quote := ""
-	if len(matches) > 1 {
-		quote = matches[1]
+	if span == nil {
+		return fmt.Errorf("could not find version pattern to replace")
 	}
 
-	// Replace the version
-	contentStr = re.ReplaceAllString(contentStr, fmt.Sprintf("${1}%s%s%s${2}", quote, newVersion, quote))
+	contentStr := string(content[:span.start]) + span.quote + newVersion + span.quote + string(content[span.end:])
 
 	// Write back to file
 	if err := os.WriteFile(filePath, []byte(contentStr), 0644); err != nil {
@@ -99,4 +94,77 @@ func (g *GalaxySource) SetVersion(filePath string, newVersion string) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// AnsibleRoleSource handles standalone roles, whose metadata lives under
+// galaxy_info in meta/main.yml.
+type AnsibleRoleSource struct{}
+
+func NewAnsibleRoleSource() VersionSource {
+	return &AnsibleRoleSource{}
+}
+
+var roleVersionKeys = []string{"galaxy_info", "version"}
+
+func (a *AnsibleRoleSource) Name() string {
+	return "meta/main.yml"
+}
+
+func (a *AnsibleRoleSource) GetDefaultFileName() string {
+	return "meta/main.yml"
+}
+
+func (a *AnsibleRoleSource) Detect(projectPath string) bool {
+	_, err := a.Locate(projectPath)
+	return err == nil
+}
+
+func (a *AnsibleRoleSource) Locate(projectPath string) (string, error) {
+	for _, name := range []string{"main.yml", "main.yaml"} {
+		filePath := filepath.Join(projectPath, "meta", name)
+		if _, err := a.GetVersion(filePath); err == nil {
+			return filePath, nil
+		}
+	}
+	return "", fmt.Errorf("no meta/main.yml with galaxy_info.version found")
+}
+
+func (a *AnsibleRoleSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	span, err := locateYAMLValue(content, roleVersionKeys)
+	if err != nil {
+		return "", err
+	}
+	if span == nil {
+		return "", fmt.Errorf("galaxy_info.version not found in %s", filepath.Base(filePath))
+	}
+
+	return span.value, nil
+}
+
+func (a *AnsibleRoleSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	span, err := locateYAMLValue(content, roleVersionKeys)
+	if err != nil {
+		return err
+	}
+	if span == nil {
+		return fmt.Errorf("could not find version pattern to replace")
+	}
+
+	output := string(content[:span.start]) + span.quote + newVersion + span.quote + string(content[span.end:])
+
+	if err := os.WriteFile(filePath, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
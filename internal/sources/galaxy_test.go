@@ -75,6 +75,26 @@ readme: README.md
 			want:    "1.0.0",
 			wantErr: false, // Regex should still find it
 		},
+		{
+			name: "unquoted version is not read as a float",
+			content: `namespace: my_namespace
+name: my_collection
+version: 1.10
+`,
+			want:    "1.10",
+			wantErr: false,
+		},
+		{
+			name: "nested version keys are ignored",
+			content: `namespace: my_namespace
+dependencies:
+  version: 0.5.0
+name: my_collection
+version:   '2.3.4'   # released
+`,
+			want:    "2.3.4",
+			wantErr: false,
+		},
 	}
 
 	g := NewGalaxySource()
@@ -211,6 +231,120 @@ func TestGalaxySource_Detect(t *testing.T) {
 	}
 }
 
+func TestGalaxySource_DetectYAMLExtension(t *testing.T) {
+	g := NewGalaxySource()
+
+	tmpDir := t.TempDir()
+	galaxyFile := filepath.Join(tmpDir, "galaxy.yaml")
+	if err := os.WriteFile(galaxyFile, []byte("version: 1.0.0"), 0644); err != nil {
+		t.Fatalf("failed to create galaxy.yaml: %v", err)
+	}
+
+	if !g.Detect(tmpDir) {
+		t.Error("Detect() = false, want true when galaxy.yaml exists")
+	}
+
+	got, err := g.(Locator).Locate(tmpDir)
+	if err != nil || got != galaxyFile {
+		t.Errorf("Locate() = %v, %v, want %v", got, err, galaxyFile)
+	}
+}
+
+func TestGalaxySource_SetVersionLeavesNestedVersions(t *testing.T) {
+	content := `namespace: my_namespace
+name: my_collection
+version: 1.2.3
+dependencies:
+  other.collection:
+    version: 1.2.3
+`
+	want := `namespace: my_namespace
+name: my_collection
+version: 1.3.0
+dependencies:
+  other.collection:
+    version: 1.2.3
+`
+
+	tmpFile := filepath.Join(t.TempDir(), "galaxy.yml")
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+
+	if err := NewGalaxySource().SetVersion(tmpFile, "1.3.0"); err != nil {
+		t.Fatalf("SetVersion() error = %v", err)
+	}
+
+	got, err := os.ReadFile(tmpFile)
+	if err != nil {
+		t.Fatalf("failed to read updated file: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("SetVersion() content =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGalaxySource_ValidateVersion(t *testing.T) {
+	g := NewGalaxySource().(VersionValidator)
+
+	if err := g.ValidateVersion("1.2.3"); err != nil {
+		t.Errorf("ValidateVersion(1.2.3) error = %v", err)
+	}
+	if err := g.ValidateVersion("v1.2.3"); err == nil {
+		t.Error("ValidateVersion(v1.2.3) should fail")
+	}
+}
+
+func TestAnsibleRoleSource(t *testing.T) {
+	content := `---
+galaxy_info:
+  role_name: web
+  author: me
+  version: "0.2.0"
+  platforms:
+    - name: Debian
+      versions:
+        - bookworm
+dependencies: []
+`
+
+	a := NewAnsibleRoleSource()
+
+	tmpDir := t.TempDir()
+	metaFile := filepath.Join(tmpDir, "meta", "main.yml")
+	if err := os.MkdirAll(filepath.Dir(metaFile), 0755); err != nil {
+		t.Fatalf("failed to create meta dir: %v", err)
+	}
+	if err := os.WriteFile(metaFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create meta/main.yml: %v", err)
+	}
+
+	if !a.Detect(tmpDir) {
+		t.Fatal("Detect() = false, want true")
+	}
+
+	got, err := a.GetVersion(metaFile)
+	if err != nil || got != "0.2.0" {
+		t.Fatalf("GetVersion() = %v, %v, want 0.2.0", got, err)
+	}
+
+	if err := a.SetVersion(metaFile, "0.3.0"); err != nil {
+		t.Fatalf("SetVersion() error = %v", err)
+	}
+
+	updated, err := os.ReadFile(metaFile)
+	if err != nil {
+		t.Fatalf("failed to read updated file: %v", err)
+	}
+	if !contains(string(updated), `  version: "0.3.0"`) || !contains(string(updated), "role_name: web") {
+		t.Errorf("SetVersion() content =\n%s", updated)
+	}
+
+	if a.Detect(t.TempDir()) {
+		t.Error("Detect() = true, want false without meta/main.yml")
+	}
+}
+
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
\ No newline at end of file
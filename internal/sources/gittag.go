@@ -0,0 +1,83 @@
+package sources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oriol/bumpr/internal/external"
+	"github.com/oriol/bumpr/internal/version"
+)
+
+// GitTagSource derives the version from the highest SemVer tag reachable from
+// HEAD. There is no file behind it, so SetVersion does nothing and releases
+// only create and push the new tag.
+type GitTagSource struct {
+	git    external.GitBackend
+	prefix string
+}
+
+func NewGitTagSource(git external.GitBackend, prefix string) VersionSource {
+	return &GitTagSource{
+		git:    git,
+		prefix: prefix,
+	}
+}
+
+func (g *GitTagSource) Name() string {
+	return "git tags"
+}
+
+func (g *GitTagSource) GetDefaultFileName() string {
+	return "."
+}
+
+func (g *GitTagSource) Detect(projectPath string) bool {
+	_, err := g.latestVersion()
+	return err == nil
+}
+
+func (g *GitTagSource) Locate(projectPath string) (string, error) {
+	return projectPath, nil
+}
+
+func (g *GitTagSource) GetVersion(filePath string) (string, error) {
+	return g.latestVersion()
+}
+
+func (g *GitTagSource) SetVersion(filePath string, newVersion string) error {
+	return nil
+}
+
+func (g *GitTagSource) IsTagOnly(filePath string) bool {
+	return true
+}
+
+func (g *GitTagSource) latestVersion() (string, error) {
+	tags, err := g.git.MergedTags(g.prefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	var latest *version.Version
+	latestStr := ""
+	for _, tag := range tags {
+		candidate := strings.TrimPrefix(tag, g.prefix)
+		v, err := version.Parse(candidate)
+		if err != nil {
+			continue
+		}
+		if latest == nil || v.Compare(latest) > 0 {
+			latest = v
+			latestStr = candidate
+		}
+	}
+
+	if latest == nil {
+		if g.prefix != "" {
+			return "", fmt.Errorf("no SemVer tags with prefix %q reachable from HEAD", g.prefix)
+		}
+		return "", fmt.Errorf("no SemVer tags reachable from HEAD")
+	}
+
+	return latestStr, nil
+}
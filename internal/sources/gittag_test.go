@@ -0,0 +1,82 @@
+package sources
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/oriol/bumpr/internal/external"
+)
+
+type tagListRunner struct {
+	tags []string
+	args []string
+}
+
+func (r *tagListRunner) Run(ctx context.Context, cmd string, args ...string) (*external.CommandResult, error) {
+	return r.RunWithOutput(ctx, cmd, args...)
+}
+
+func (r *tagListRunner) RunWithInput(ctx context.Context, input string, cmd string, args ...string) (*external.CommandResult, error) {
+	return r.RunWithOutput(ctx, cmd, args...)
+}
+
+func (r *tagListRunner) RunWithOutput(ctx context.Context, cmd string, args ...string) (*external.CommandResult, error) {
+	r.args = args
+	return &external.CommandResult{Command: cmd, Args: args, Stdout: strings.Join(r.tags, "\n") + "\n"}, nil
+}
+
+func TestGitTagSource_GetVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		tags    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "highest semver wins over lexical order",
+			tags: []string{"1.2.0", "1.10.0", "1.9.3", "nightly"},
+			want: "1.10.0",
+		},
+		{
+			name: "v prefixed tags without configured prefix",
+			tags: []string{"v0.1.0", "v0.2.0"},
+			want: "v0.2.0",
+		},
+		{
+			name:   "configured prefix is stripped",
+			prefix: "api/",
+			tags:   []string{"api/1.0.0", "api/1.1.0", "api/v2"},
+			want:   "1.1.0",
+		},
+		{
+			name:    "no semver tags",
+			tags:    []string{"latest"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &tagListRunner{tags: tt.tags}
+			source := NewGitTagSource(external.NewGitCommands(runner, false), tt.prefix)
+
+			got, err := source.GetVersion(".")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetVersion() = %v, want %v", got, tt.want)
+			}
+			if source.Detect(".") == tt.wantErr {
+				t.Errorf("Detect() = %v, want %v", tt.wantErr, !tt.wantErr)
+			}
+
+			wantArgs := "tag --list " + tt.prefix + "* --merged HEAD"
+			if strings.Join(runner.args, " ") != wantArgs {
+				t.Errorf("git args = %q, want %q", strings.Join(runner.args, " "), wantArgs)
+			}
+		})
+	}
+}
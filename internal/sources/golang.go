@@ -0,0 +1,246 @@
+package sources
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/oriol/bumpr/internal/version"
+)
+
+type GoSource struct{}
+
+func NewGoSource() VersionSource {
+	return &GoSource{}
+}
+
+var (
+	goVersionValue = regexp.MustCompile(`^v?\d+\.\d+\.\d+`)
+	goModuleLine   = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?`)
+	goMajorSuffix  = regexp.MustCompile(`/v(\d+)$`)
+)
+
+func (g *GoSource) Name() string {
+	return "version.go"
+}
+
+func (g *GoSource) GetDefaultFileName() string {
+	return "version.go"
+}
+
+func (g *GoSource) Detect(projectPath string) bool {
+	// Only Go modules are searched, walking every other tree would be wasteful
+	if _, err := os.Stat(filepath.Join(projectPath, "go.mod")); err != nil {
+		return false
+	}
+
+	_, err := g.Locate(projectPath)
+	return err == nil
+}
+
+func (g *GoSource) Locate(projectPath string) (string, error) {
+	preferred := filepath.Join(projectPath, g.GetDefaultFileName())
+	if g.hasVersionDecl(preferred) {
+		return preferred, nil
+	}
+
+	found := ""
+	err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if path != projectPath && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		if g.hasVersionDecl(path) {
+			found = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to search for Go files: %w", err)
+	}
+
+	if found == "" {
+		return "", fmt.Errorf("no Version declaration found in Go files")
+	}
+
+	return found, nil
+}
+
+func (g *GoSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	lit, _, err := findGoVersionLiteral(filePath, content)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Version literal: %w", err)
+	}
+
+	return value, nil
+}
+
+func (g *GoSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	lit, fset, err := findGoVersionLiteral(filePath, content)
+	if err != nil {
+		return err
+	}
+
+	// Keep raw string literals raw, everything else becomes an interpreted string
+	replacement := strconv.Quote(newVersion)
+	if strings.HasPrefix(lit.Value, "`") && !strings.Contains(newVersion, "`") {
+		replacement = "`" + newVersion + "`"
+	}
+
+	start := fset.Position(lit.Pos()).Offset
+	end := fset.Position(lit.End()).Offset
+
+	output := make([]byte, 0, len(content)+len(replacement))
+	output = append(output, content[:start]...)
+	output = append(output, replacement...)
+	output = append(output, content[end:]...)
+
+	if err := os.WriteFile(filePath, output, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// Advise reports when a major bump crosses the semantic import versioning
+// rule: modules at v2 and above must end their module path in /vN.
+func (g *GoSource) Advise(filePath, currentVersion, newVersion string) []string {
+	current, err := version.Parse(currentVersion)
+	if err != nil {
+		return nil
+	}
+	next, err := version.Parse(newVersion)
+	if err != nil || next.Major == current.Major {
+		return nil
+	}
+
+	goModPath, modulePath := findGoModule(filepath.Dir(filePath))
+	if goModPath == "" {
+		return nil
+	}
+
+	suffixMajor := 0
+	if matches := goMajorSuffix.FindStringSubmatch(modulePath); matches != nil {
+		suffixMajor, _ = strconv.Atoi(matches[1])
+	}
+
+	wantMajor := next.Major
+	if wantMajor < 2 {
+		wantMajor = 0
+	}
+	if suffixMajor == wantMajor {
+		return nil
+	}
+
+	basePath := goMajorSuffix.ReplaceAllString(modulePath, "")
+	wantPath := basePath
+	if wantMajor >= 2 {
+		wantPath = fmt.Sprintf("%s/v%d", basePath, wantMajor)
+	}
+
+	return []string{
+		fmt.Sprintf("major version %d requires the module path %q in %s (currently %q); update go.mod and import paths",
+			next.Major, wantPath, goModPath, modulePath),
+	}
+}
+
+func (g *GoSource) hasVersionDecl(filePath string) bool {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return false
+	}
+
+	lit, _, err := findGoVersionLiteral(filePath, content)
+	if err != nil {
+		return false
+	}
+
+	value, err := strconv.Unquote(lit.Value)
+	return err == nil && goVersionValue.MatchString(value)
+}
+
+func findGoVersionLiteral(filePath string, content []byte) (*ast.BasicLit, *token.FileSet, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Go file: %w", err)
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+
+			for i, name := range valueSpec.Names {
+				if name.Name != "Version" || i >= len(valueSpec.Values) {
+					continue
+				}
+				if lit, ok := valueSpec.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					return lit, fset, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no string Version const or var declaration found in %s", filepath.Base(filePath))
+}
+
+func findGoModule(dir string) (string, string) {
+	for {
+		goModPath := filepath.Join(dir, "go.mod")
+		if content, err := os.ReadFile(goModPath); err == nil {
+			if matches := goModuleLine.FindSubmatch(content); matches != nil {
+				return goModPath, string(matches[1])
+			}
+			return "", ""
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
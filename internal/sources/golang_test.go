@@ -0,0 +1,235 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoSource_GetVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "const declaration",
+			content: `package main
+
+const Version = "1.2.3"
+`,
+			want:    "1.2.3",
+			wantErr: false,
+		},
+		{
+			name: "var block",
+			content: `package cmd
+
+var (
+	// Set at build time
+	Version   = "v0.4.0"
+	BuildTime = "unknown"
+)
+`,
+			want:    "v0.4.0",
+			wantErr: false,
+		},
+		{
+			name:    "raw string",
+			content: "package main\n\nconst Version = `2.0.0`\n",
+			want:    "2.0.0",
+			wantErr: false,
+		},
+		{
+			name: "no Version declaration",
+			content: `package main
+
+const Name = "tool"
+`,
+			want:    "",
+			wantErr: true,
+		},
+	}
+
+	g := NewGoSource()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(t.TempDir(), "version.go")
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create temp file: %v", err)
+			}
+
+			got, err := g.GetVersion(tmpFile)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoSource_SetVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		newVersion string
+		want       string
+	}{
+		{
+			name: "aligned var block keeps formatting",
+			content: `package cmd
+
+var (
+	Version   = "1.2.3" // current release
+	BuildTime = "unknown"
+)
+`,
+			newVersion: "1.3.0",
+			want: `package cmd
+
+var (
+	Version   = "1.3.0" // current release
+	BuildTime = "unknown"
+)
+`,
+		},
+		{
+			name:       "raw string stays raw",
+			content:    "package main\n\nconst Version = `1.2.3`\n",
+			newVersion: "2.0.0",
+			want:       "package main\n\nconst Version = `2.0.0`\n",
+		},
+	}
+
+	g := NewGoSource()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(t.TempDir(), "version.go")
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create temp file: %v", err)
+			}
+
+			if err := g.SetVersion(tmpFile, tt.newVersion); err != nil {
+				t.Fatalf("SetVersion() error = %v", err)
+			}
+
+			got, err := os.ReadFile(tmpFile)
+			if err != nil {
+				t.Fatalf("failed to read updated file: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("SetVersion() content =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoSource_Locate(t *testing.T) {
+	g := NewGoSource()
+
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                  "module example.com/tool\n",
+		"main.go":                 "package main\n\nvar Version = \"dev\"\n",
+		"internal/meta/meta.go":   "package meta\n\nconst Version = \"0.3.1\"\n",
+		"vendor/x/version.go":     "package x\n\nconst Version = \"9.9.9\"\n",
+		"internal/meta/x_test.go": "package meta\n\nconst Version = \"8.8.8\"\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	if !g.Detect(tmpDir) {
+		t.Fatal("Detect() = false, want true")
+	}
+
+	got, err := g.(Locator).Locate(tmpDir)
+	if err != nil {
+		t.Fatalf("Locate() error = %v", err)
+	}
+	if want := filepath.Join(tmpDir, "internal", "meta", "meta.go"); got != want {
+		t.Errorf("Locate() = %v, want %v", got, want)
+	}
+
+	if g.Detect(t.TempDir()) {
+		t.Error("Detect() = true, want false without go.mod")
+	}
+}
+
+func TestGoSource_Advise(t *testing.T) {
+	tests := []struct {
+		name       string
+		module     string
+		current    string
+		newVersion string
+		wantWarn   string
+	}{
+		{
+			name:       "minor bump",
+			module:     "example.com/tool",
+			current:    "1.2.3",
+			newVersion: "1.3.0",
+		},
+		{
+			name:       "v1 to v2 needs suffix",
+			module:     "example.com/tool",
+			current:    "1.2.3",
+			newVersion: "2.0.0",
+			wantWarn:   `"example.com/tool/v2"`,
+		},
+		{
+			name:       "v2 to v3 needs new suffix",
+			module:     "example.com/tool/v2",
+			current:    "v2.4.0",
+			newVersion: "v3.0.0",
+			wantWarn:   `"example.com/tool/v3"`,
+		},
+		{
+			name:       "suffix already updated",
+			module:     "example.com/tool/v2",
+			current:    "1.9.0",
+			newVersion: "2.0.0",
+		},
+		{
+			name:       "v0 to v1",
+			module:     "example.com/tool",
+			current:    "0.9.0",
+			newVersion: "1.0.0",
+		},
+	}
+
+	g := NewGoSource().(Advisor)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module "+tt.module+"\n\ngo 1.23\n"), 0644); err != nil {
+				t.Fatalf("failed to create go.mod: %v", err)
+			}
+
+			warnings := g.Advise(filepath.Join(tmpDir, "version.go"), tt.current, tt.newVersion)
+			if tt.wantWarn == "" {
+				if len(warnings) != 0 {
+					t.Errorf("Advise() = %v, want no warnings", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], tt.wantWarn) {
+				t.Errorf("Advise() = %v, want warning containing %s", warnings, tt.wantWarn)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/oriol/bumpr/internal/config"
+)
+
+// Tags that look like a version; latest, main, sha-... are left alone
+var imageVersionTag = regexp.MustCompile(`^v?\d+(\.\d+)*([-+][\w.-]*)?$`)
+
+// ImageUpdater rewrites the tag of a container image wherever it is referenced
+// with a version tag, e.g. "image: myorg/app:1.2.3" in docker-compose.yml or
+// Kubernetes manifests. It is not a version source: it runs after the source
+// was updated so the files land in the release commit.
+type ImageUpdater struct {
+	name  string
+	files []string
+	tag   string
+	re    *regexp.Regexp
+}
+
+func NewImageUpdater(ic config.ImageConfig) *ImageUpdater {
+	return &ImageUpdater{
+		name:  ic.Name,
+		files: ic.Files,
+		tag:   ic.Tag,
+		re:    regexp.MustCompile(regexp.QuoteMeta(ic.Name) + `:(\w[\w.-]{0,127})`),
+	}
+}
+
+func (u *ImageUpdater) Name() string {
+	return u.name
+}
+
+// Files resolves the configured files and glob patterns against the project
+// root. Every entry has to match at least one file.
+func (u *ImageUpdater) Files(projectPath string) ([]string, error) {
+	var files []string
+	seen := map[string]bool{}
+
+	for _, pattern := range u.files {
+		matches, err := filepath.Glob(filepath.Join(projectPath, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid file pattern %q for image %s: %w", pattern, u.name, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no file matches %q for image %s", pattern, u.name)
+		}
+
+		sort.Strings(matches)
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				files = append(files, match)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// UpdateFiles sets the tag of every versioned reference to the image in files,
+// as resolved by Files, and returns the files that changed.
+func (u *ImageUpdater) UpdateFiles(files []string, newVersion string) ([]string, error) {
+	var changed []string
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+
+		updated := u.rewrite(string(content), newVersion)
+		if updated == string(content) {
+			continue
+		}
+
+		if err := os.WriteFile(file, []byte(updated), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write file: %w", err)
+		}
+		changed = append(changed, file)
+	}
+
+	return changed, nil
+}
+
+func (u *ImageUpdater) rewrite(content, newVersion string) string {
+	var b strings.Builder
+	last := 0
+
+	for _, match := range u.re.FindAllStringSubmatchIndex(content, -1) {
+		start, tagStart, tagEnd := match[0], match[2], match[3]
+
+		// Skip longer image names (otherorg/myorg/app, myorg/app-worker) and pinned digests
+		if start > 0 && strings.ContainsRune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_./-", rune(content[start-1])) {
+			continue
+		}
+		if tagEnd < len(content) && content[tagEnd] == '@' {
+			continue
+		}
+
+		current := content[tagStart:tagEnd]
+		if !imageVersionTag.MatchString(current) {
+			continue
+		}
+
+		b.WriteString(content[last:tagStart])
+		b.WriteString(u.renderTag(current, newVersion))
+		last = tagEnd
+	}
+
+	b.WriteString(content[last:])
+	return b.String()
+}
+
+// renderTag applies the configured template, or keeps the v prefix style of
+// the current tag.
+func (u *ImageUpdater) renderTag(current, newVersion string) string {
+	// Image tags cannot contain "+", build metadata is joined with "_" instead
+	bare := strings.ReplaceAll(strings.TrimPrefix(newVersion, "v"), "+", "_")
+	if u.tag != "" {
+		return strings.ReplaceAll(u.tag, "{new_version}", bare)
+	}
+	if strings.HasPrefix(current, "v") {
+		return "v" + bare
+	}
+	return bare
+}
@@ -0,0 +1,108 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oriol/bumpr/internal/config"
+)
+
+func TestImageUpdater_UpdateFiles(t *testing.T) {
+	compose := `services:
+  app:
+    image: myorg/app:1.2.3
+  worker:
+    image: "myorg/app:v1.2.3"
+  sidecar:
+    image: myorg/app-worker:1.2.3
+  mirror:
+    image: registry.example.com/myorg/app:1.2.3
+  dev:
+    image: myorg/app:latest
+  pinned:
+    image: myorg/app:1.2.3@sha256:0123456789abcdef
+`
+	wantCompose := `services:
+  app:
+    image: myorg/app:1.3.0
+  worker:
+    image: "myorg/app:v1.3.0"
+  sidecar:
+    image: myorg/app-worker:1.2.3
+  mirror:
+    image: registry.example.com/myorg/app:1.2.3
+  dev:
+    image: myorg/app:latest
+  pinned:
+    image: myorg/app:1.2.3@sha256:0123456789abcdef
+`
+	deployment := `spec:
+  containers:
+    - name: app
+      image: myorg/app:1.2.3 # bumped on release
+`
+	wantDeployment := `spec:
+  containers:
+    - name: app
+      image: myorg/app:1.3.0 # bumped on release
+`
+
+	projectDir := writeProjectFiles(t, map[string]string{
+		"docker-compose.yml":  compose,
+		"k8s/deployment.yaml": deployment,
+		"k8s/service.yaml":    "kind: Service\n",
+	})
+
+	updater := NewImageUpdater(config.ImageConfig{Name: "myorg/app", Files: []string{"docker-compose.yml", "k8s/*.yaml"}})
+
+	files, err := updater.Files(projectDir)
+	if err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+	if len(files) != 3 {
+		t.Errorf("Files() = %v, want docker-compose.yml and both k8s files", files)
+	}
+
+	changed, err := updater.UpdateFiles(files, "1.3.0")
+	if err != nil {
+		t.Fatalf("UpdateFiles() error = %v", err)
+	}
+	if len(changed) != 2 || changed[0] != filepath.Join(projectDir, "docker-compose.yml") || changed[1] != filepath.Join(projectDir, "k8s", "deployment.yaml") {
+		t.Errorf("UpdateFiles() changed = %v, want docker-compose.yml and k8s/deployment.yaml", changed)
+	}
+
+	for file, want := range map[string]string{"docker-compose.yml": wantCompose, "k8s/deployment.yaml": wantDeployment} {
+		got, _ := os.ReadFile(filepath.Join(projectDir, file))
+		if string(got) != want {
+			t.Errorf("%s =\n%s\nwant:\n%s", file, got, want)
+		}
+	}
+}
+
+func TestImageUpdater_TagTemplate(t *testing.T) {
+	projectDir := writeProjectFiles(t, map[string]string{
+		"Dockerfile": "FROM ghcr.io/myorg/base:2.0.0-alpine\n",
+	})
+
+	updater := NewImageUpdater(config.ImageConfig{Name: "ghcr.io/myorg/base", Files: []string{"Dockerfile"}, Tag: "{new_version}-alpine"})
+	files, err := updater.Files(projectDir)
+	if err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+	if _, err := updater.UpdateFiles(files, "v2.1.0+build.7"); err != nil {
+		t.Fatalf("UpdateFiles() error = %v", err)
+	}
+
+	got, _ := os.ReadFile(filepath.Join(projectDir, "Dockerfile"))
+	if want := "FROM ghcr.io/myorg/base:2.1.0_build.7-alpine\n"; string(got) != want {
+		t.Errorf("Dockerfile = %q, want %q", got, want)
+	}
+}
+
+func TestImageUpdater_MissingFile(t *testing.T) {
+	updater := NewImageUpdater(config.ImageConfig{Name: "myorg/app", Files: []string{"deploy/*.yaml"}})
+	if _, err := updater.Files(t.TempDir()); err == nil {
+		t.Error("Files() should fail when a pattern matches no file")
+	}
+}
@@ -1,9 +1,60 @@
 package sources
 
+import "github.com/oriol/bumpr/internal/external"
+
 type VersionSource interface {
 	Name() string
 	Detect(projectPath string) bool
 	GetVersion(filePath string) (string, error)
 	SetVersion(filePath string, newVersion string) error
 	GetDefaultFileName() string
-}
\ No newline at end of file
+}
+
+// Locator is implemented by sources whose file does not live at a fixed
+// path relative to the project root.
+type Locator interface {
+	Locate(projectPath string) (string, error)
+}
+
+// FileMatcher is implemented by sources sharing a default file name with
+// another source, e.g. package.json, to claim only the files they handle.
+type FileMatcher interface {
+	MatchesFile(filePath string) bool
+}
+
+// Advisor is implemented by sources that can warn about side effects of a
+// version change that bumpr cannot apply by itself.
+type Advisor interface {
+	Advise(filePath, currentVersion, newVersion string) []string
+}
+
+// TagOnlySource is implemented by sources whose version can live in git tags
+// alone. When IsTagOnly reports true there is no file to update or commit.
+type TagOnlySource interface {
+	IsTagOnly(filePath string) bool
+}
+
+// TagValidator is implemented by sources that read the version back from
+// the release tag, to refuse tag names they would not recognise.
+type TagValidator interface {
+	ValidateTag(filePath, tagName, newVersion string) error
+}
+
+// ChangelogSource is implemented by sources that record release notes next to
+// the version. The orchestrator hands over the subjects of the commits since
+// the previous release before calling SetVersion.
+type ChangelogSource interface {
+	SetChanges(changes []string)
+}
+
+// VersionValidator is implemented by sources whose file format restricts
+// which versions may be written.
+type VersionValidator interface {
+	ValidateVersion(version string) error
+}
+
+// GitSource is implemented by sources that may read their version from git,
+// e.g. from tags. The detector hands over the git backend it was given.
+type GitSource interface {
+	SetGit(git external.GitBackend)
+}
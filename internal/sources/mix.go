@@ -0,0 +1,74 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+type MixSource struct{}
+
+func NewMixSource() VersionSource {
+	return &MixSource{}
+}
+
+// A module attribute is preferred, since "version: @version" then refers to it
+var mixVersionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^(\s*@version\s+")([^"]+)(")`),
+	regexp.MustCompile(`(\bversion:\s*")([^"]+)(")`),
+}
+
+func (m *MixSource) Name() string {
+	return "mix.exs"
+}
+
+func (m *MixSource) GetDefaultFileName() string {
+	return "mix.exs"
+}
+
+func (m *MixSource) Detect(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, "mix.exs"))
+	return err == nil
+}
+
+func (m *MixSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	for _, re := range mixVersionPatterns {
+		if matches := re.FindSubmatch(content); matches != nil {
+			return string(matches[2]), nil
+		}
+	}
+
+	return "", fmt.Errorf("version not found in mix.exs")
+}
+
+func (m *MixSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	for _, re := range mixVersionPatterns {
+		indexes := re.FindSubmatchIndex(content)
+		if indexes == nil {
+			continue
+		}
+
+		output := make([]byte, 0, len(content)+len(newVersion))
+		output = append(output, content[:indexes[4]]...)
+		output = append(output, newVersion...)
+		output = append(output, content[indexes[5]:]...)
+
+		if err := os.WriteFile(filePath, output, 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("could not find version pattern to replace")
+}
@@ -0,0 +1,88 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// NixSource edits the first `version = "...";` binding of a Nix expression
+// without evaluating it.
+type NixSource struct{}
+
+func NewNixSource() VersionSource {
+	return &NixSource{}
+}
+
+var nixFiles = []string{"flake.nix", "default.nix", "package.nix", "nix/default.nix", "nix/package.nix"}
+
+// Interpolated values such as "${base}-rc" are not literals and are skipped
+var nixVersionPattern = regexp.MustCompile(`(\bversion\s*=\s*")([^"$\\]+)(";)`)
+
+func (n *NixSource) Name() string {
+	return "*.nix"
+}
+
+func (n *NixSource) GetDefaultFileName() string {
+	return "default.nix"
+}
+
+func (n *NixSource) Detect(projectPath string) bool {
+	_, err := n.Locate(projectPath)
+	return err == nil
+}
+
+func (n *NixSource) Locate(projectPath string) (string, error) {
+	for _, name := range nixFiles {
+		path := filepath.Join(projectPath, name)
+		if _, err := n.GetVersion(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no Nix expression with a version found")
+}
+
+func (n *NixSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	matches := nixVersionPattern.FindSubmatch(content)
+	if matches == nil {
+		return "", fmt.Errorf("version not found in %s", filepath.Base(filePath))
+	}
+
+	return string(matches[2]), nil
+}
+
+func (n *NixSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	contentStr := string(content)
+	if !nixVersionPattern.MatchString(contentStr) {
+		return fmt.Errorf("could not find version pattern to replace")
+	}
+
+	contentStr = replaceFirst(nixVersionPattern, contentStr, "${1}"+newVersion+"${3}")
+
+	if err := os.WriteFile(filePath, []byte(contentStr), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func (n *NixSource) Advise(filePath, currentVersion, newVersion string) []string {
+	content, err := os.ReadFile(filePath)
+	if err != nil || !regexp.MustCompile(`\bfetch(?:url|zip|git|FromGitHub|FromGitLab)\b`).Match(content) {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("%s fetches its source; update the hash for %s once the release is published", filepath.Base(filePath), newVersion),
+	}
+}
@@ -0,0 +1,186 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var packagingNow = func() time.Time {
+	return time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+}
+
+func TestDebianChangelogSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		changes    []string
+		want       string
+		newVersion string
+		wantHead   string
+	}{
+		{
+			name: "revision is reset and fields are kept",
+			content: `mytool (1:1.2.3-2) bookworm; urgency=low
+
+  * Rebuild.
+
+ -- Jane Doe <jane@example.com>  Mon, 01 Jan 2024 10:00:00 +0000
+`,
+			changes:    []string{"Add feature", "Fix bug"},
+			want:       "1.2.3",
+			newVersion: "1.3.0",
+			wantHead: `mytool (1:1.3.0-1) bookworm; urgency=low
+
+  * Add feature
+  * Fix bug
+
+ -- Jane Doe <jane@example.com>  Tue, 05 Mar 2024 14:30:00 +0000
+
+mytool (1:1.2.3-2) bookworm; urgency=low
+`,
+		},
+		{
+			name: "native package without changes",
+			content: `native (0.9.0) unstable; urgency=medium
+
+  * Initial release.
+
+ -- Jane Doe <jane@example.com>  Mon, 01 Jan 2024 10:00:00 +0000
+`,
+			want:       "0.9.0",
+			newVersion: "0.9.1",
+			wantHead: `native (0.9.1) unstable; urgency=medium
+
+  * New upstream release 0.9.1.
+
+ -- Jane Doe <jane@example.com>  Tue, 05 Mar 2024 14:30:00 +0000
+
+`,
+		},
+	}
+
+	t.Setenv("DEBEMAIL", "")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			tmpFile := filepath.Join(tmpDir, "debian", "changelog")
+			if err := os.MkdirAll(filepath.Dir(tmpFile), 0755); err != nil {
+				t.Fatalf("failed to create dir: %v", err)
+			}
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create temp file: %v", err)
+			}
+
+			source := &DebianChangelogSource{now: packagingNow}
+			if !source.Detect(tmpDir) {
+				t.Error("Detect() = false, want true")
+			}
+
+			got, err := source.GetVersion(tmpFile)
+			if err != nil {
+				t.Fatalf("GetVersion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetVersion() = %v, want %v", got, tt.want)
+			}
+
+			source.SetChanges(tt.changes)
+			if err := source.SetVersion(tmpFile, tt.newVersion); err != nil {
+				t.Fatalf("SetVersion() error = %v", err)
+			}
+
+			content, err := os.ReadFile(tmpFile)
+			if err != nil {
+				t.Fatalf("failed to read updated file: %v", err)
+			}
+			if !strings.HasPrefix(string(content), tt.wantHead) {
+				t.Errorf("SetVersion() content =\n%s\nwant prefix:\n%s", content, tt.wantHead)
+			}
+
+			got, err = source.GetVersion(tmpFile)
+			if err != nil || got != tt.newVersion {
+				t.Errorf("GetVersion() after SetVersion() = %v, %v, want %v", got, err, tt.newVersion)
+			}
+		})
+	}
+}
+
+func TestDebianChangelogSource_Maintainer(t *testing.T) {
+	t.Setenv("DEBFULLNAME", "Release Bot")
+	t.Setenv("DEBEMAIL", "bot@example.com")
+
+	if got := debianMaintainer(); got != "Release Bot <bot@example.com>" {
+		t.Errorf("debianMaintainer() = %q", got)
+	}
+}
+
+func TestRPMSpecSource(t *testing.T) {
+	content := `Name:           mytool
+Version:        1.2.3
+Release:        4%{?dist}
+Summary:        A tool
+
+%description
+A tool.
+
+%changelog
+* Mon Jan 01 2024 Jane Doe <jane@example.com> - 1.2.3-4
+- Rebuild
+`
+	want := `Name:           mytool
+Version:        1.3.0
+Release:        1%{?dist}
+Summary:        A tool
+
+%description
+A tool.
+
+%changelog
+* Tue Mar 05 2024 Jane Doe <jane@example.com> - 1.3.0-1
+- Add feature
+- Fix bug
+
+* Mon Jan 01 2024 Jane Doe <jane@example.com> - 1.2.3-4
+- Rebuild
+`
+
+	t.Setenv("RPM_PACKAGER", "")
+
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "mytool.spec")
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+
+	source := &RPMSpecSource{now: packagingNow}
+
+	located, err := source.Locate(tmpDir)
+	if err != nil || located != tmpFile {
+		t.Fatalf("Locate() = %v, %v, want %v", located, err, tmpFile)
+	}
+
+	got, err := source.GetVersion(tmpFile)
+	if err != nil {
+		t.Fatalf("GetVersion() error = %v", err)
+	}
+	if got != "1.2.3" {
+		t.Errorf("GetVersion() = %v, want 1.2.3", got)
+	}
+
+	source.SetChanges([]string{"Add feature", "Fix bug"})
+	if err := source.SetVersion(tmpFile, "1.3.0"); err != nil {
+		t.Fatalf("SetVersion() error = %v", err)
+	}
+
+	updated, err := os.ReadFile(tmpFile)
+	if err != nil {
+		t.Fatalf("failed to read updated file: %v", err)
+	}
+	if string(updated) != want {
+		t.Errorf("SetVersion() content =\n%s\nwant:\n%s", updated, want)
+	}
+}
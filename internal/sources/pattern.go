@@ -0,0 +1,138 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	currentVersionPlaceholder = "{current_version}"
+	newVersionPlaceholder     = "{new_version}"
+	versionGroup              = "version"
+)
+
+// PatternSource reads and writes the version in an arbitrary file through a
+// user supplied regular expression with a named "version" group, or through
+// a bump2version style search/replace template pair.
+type PatternSource struct {
+	file    string
+	pattern *regexp.Regexp
+	group   int
+	replace string
+}
+
+func NewPatternSource(file, pattern string) (VersionSource, error) {
+	re, err := regexp.Compile("(?m)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern for %s: %w", file, err)
+	}
+
+	group := re.SubexpIndex(versionGroup)
+	if group < 0 {
+		return nil, fmt.Errorf("pattern for %s must contain a named group (?P<version>...)", file)
+	}
+
+	return &PatternSource{file: file, pattern: re, group: group}, nil
+}
+
+func NewTemplatePatternSource(file, search, replace string) (VersionSource, error) {
+	if strings.Count(search, currentVersionPlaceholder) != 1 {
+		return nil, fmt.Errorf("search template for %s must contain %s exactly once", file, currentVersionPlaceholder)
+	}
+	if replace == "" {
+		replace = strings.Replace(search, currentVersionPlaceholder, newVersionPlaceholder, 1)
+	}
+	if !strings.Contains(replace, newVersionPlaceholder) {
+		return nil, fmt.Errorf("replace template for %s must contain %s", file, newVersionPlaceholder)
+	}
+
+	parts := strings.SplitN(search, currentVersionPlaceholder, 2)
+	pattern := regexp.QuoteMeta(parts[0]) + `(?P<version>[0-9A-Za-z][0-9A-Za-z.+_-]*)` + regexp.QuoteMeta(parts[1])
+
+	source, err := NewPatternSource(file, pattern)
+	if err != nil {
+		return nil, err
+	}
+	source.(*PatternSource).replace = replace
+
+	return source, nil
+}
+
+func (p *PatternSource) Name() string {
+	return "pattern:" + p.file
+}
+
+func (p *PatternSource) GetDefaultFileName() string {
+	return p.file
+}
+
+func (p *PatternSource) Detect(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, p.file))
+	return err == nil
+}
+
+func (p *PatternSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	matches := p.pattern.FindSubmatch(content)
+	if matches == nil || len(matches[p.group]) == 0 {
+		return "", fmt.Errorf("pattern %q did not match in %s", p.pattern.String(), filepath.Base(filePath))
+	}
+
+	return string(matches[p.group]), nil
+}
+
+func (p *PatternSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	indexes := p.pattern.FindAllSubmatchIndex(content, -1)
+	if len(indexes) == 0 {
+		return fmt.Errorf("could not find version pattern to replace")
+	}
+
+	// Like bump2version, only matches of the current version are rewritten,
+	// others are e.g. changelog entries or dependency pins
+	first := indexes[0]
+	currentVersion := string(content[first[2*p.group]:first[2*p.group+1]])
+
+	var output []byte
+	last := 0
+	for _, idx := range indexes {
+		start, end := idx[2*p.group], idx[2*p.group+1]
+		if start < 0 || string(content[start:end]) != currentVersion {
+			continue
+		}
+
+		if p.replace != "" {
+			// Template mode rewrites the whole match
+			replacement := strings.ReplaceAll(p.replace, newVersionPlaceholder, newVersion)
+			replacement = strings.ReplaceAll(replacement, currentVersionPlaceholder, currentVersion)
+
+			output = append(output, content[last:idx[0]]...)
+			output = append(output, replacement...)
+			last = idx[1]
+			continue
+		}
+
+		// Pattern mode only rewrites the version group
+		output = append(output, content[last:start]...)
+		output = append(output, newVersion...)
+		last = end
+	}
+	output = append(output, content[last:]...)
+
+	if err := os.WriteFile(filePath, output, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
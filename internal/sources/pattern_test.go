@@ -0,0 +1,122 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPatternSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		pattern    string
+		search     string
+		replace    string
+		content    string
+		want       string
+		newVersion string
+		wantAfter  string
+	}{
+		{
+			name:       "dockerfile label",
+			pattern:    `^LABEL version="(?P<version>[^"]+)"`,
+			content:    "FROM alpine\nLABEL version=\"1.2.3\"\nRUN true\n",
+			want:       "1.2.3",
+			newVersion: "1.3.0",
+			wantAfter:  "FROM alpine\nLABEL version=\"1.3.0\"\nRUN true\n",
+		},
+		{
+			name:       "cmake project",
+			pattern:    `project\(\w+ VERSION (?P<version>[0-9.]+)\)`,
+			content:    "cmake_minimum_required(VERSION 3.20)\nproject(foo VERSION 0.9.1)\n",
+			want:       "0.9.1",
+			newVersion: "1.0.0",
+			wantAfter:  "cmake_minimum_required(VERSION 3.20)\nproject(foo VERSION 1.0.0)\n",
+		},
+		{
+			name:       "readme badges replace every match",
+			search:     "version-{current_version}-blue",
+			content:    "![v](https://img.shields.io/badge/version-2.0.0-blue)\n![v](https://img.shields.io/badge/version-2.0.0-blue)\n",
+			want:       "2.0.0",
+			newVersion: "2.0.1",
+			wantAfter:  "![v](https://img.shields.io/badge/version-2.0.1-blue)\n![v](https://img.shields.io/badge/version-2.0.1-blue)\n",
+		},
+		{
+			name:       "matches of other versions are kept",
+			pattern:    `version = "(?P<version>[^"]+)"`,
+			content:    "version = \"1.4.0\"\n\n[dependencies.core]\nversion = \"0.9.2\"\n",
+			want:       "1.4.0",
+			newVersion: "1.5.0",
+			wantAfter:  "version = \"1.5.0\"\n\n[dependencies.core]\nversion = \"0.9.2\"\n",
+		},
+		{
+			name:       "template matches of other versions are kept",
+			search:     "version-{current_version}-blue",
+			content:    "version-2.0.0-blue\nversion-1.9.0-blue\nversion-2.0.0-blue\n",
+			want:       "2.0.0",
+			newVersion: "2.0.1",
+			wantAfter:  "version-2.0.1-blue\nversion-1.9.0-blue\nversion-2.0.1-blue\n",
+		},
+		{
+			name:       "search and replace templates",
+			search:     "version: '{current_version}'",
+			replace:    "version: '{new_version}' # was {current_version}",
+			content:    "project('foo', 'c', version: '0.1.0')\n",
+			want:       "0.1.0",
+			newVersion: "0.2.0",
+			wantAfter:  "project('foo', 'c', version: '0.2.0' # was 0.1.0)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(t.TempDir(), "versioned")
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create temp file: %v", err)
+			}
+
+			var source VersionSource
+			var err error
+			if tt.search != "" {
+				source, err = NewTemplatePatternSource("versioned", tt.search, tt.replace)
+			} else {
+				source, err = NewPatternSource("versioned", tt.pattern)
+			}
+			if err != nil {
+				t.Fatalf("failed to create source: %v", err)
+			}
+
+			got, err := source.GetVersion(tmpFile)
+			if err != nil {
+				t.Fatalf("GetVersion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetVersion() = %v, want %v", got, tt.want)
+			}
+
+			if err := source.SetVersion(tmpFile, tt.newVersion); err != nil {
+				t.Fatalf("SetVersion() error = %v", err)
+			}
+
+			content, err := os.ReadFile(tmpFile)
+			if err != nil {
+				t.Fatalf("failed to read updated file: %v", err)
+			}
+			if string(content) != tt.wantAfter {
+				t.Errorf("SetVersion() content =\n%s\nwant:\n%s", content, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestNewPatternSource_Invalid(t *testing.T) {
+	if _, err := NewPatternSource("Dockerfile", `version=(\S+)`); err == nil {
+		t.Error("NewPatternSource() without a version group should fail")
+	}
+	if _, err := NewPatternSource("Dockerfile", `version=(?P<version>`); err == nil {
+		t.Error("NewPatternSource() with an invalid regex should fail")
+	}
+	if _, err := NewTemplatePatternSource("README.md", "version-blue", ""); err == nil {
+		t.Error("NewTemplatePatternSource() without placeholder should fail")
+	}
+}
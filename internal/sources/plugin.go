@@ -0,0 +1,176 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/oriol/bumpr/internal/external"
+)
+
+// PluginPrefix is the name prefix of plugin executables discovered on PATH.
+const PluginPrefix = "bumpr-source-"
+
+// PluginSource delegates to an external executable. Each operation starts the
+// executable once, writes a JSON request to its stdin and reads a JSON
+// response from its stdout:
+//
+//	{"command": "detect", "project": "/abs/root", "file": "hint"} → {"found": true, "file": "path"}
+//	{"command": "get", "file": "/abs/path"}                         → {"version": "1.2.3"}
+//	{"command": "set", "file": "/abs/path", "version": "1.2.4"}     → {}
+//
+// Any response may carry {"error": "..."} instead, and a non-zero exit status
+// is treated as a failure too. Relative files in detect responses are resolved
+// against the project root.
+type PluginSource struct {
+	runner  external.CommandRunner
+	name    string
+	command string
+	file    string
+}
+
+type pluginRequest struct {
+	Command string `json:"command"`
+	Project string `json:"project,omitempty"`
+	File    string `json:"file,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type pluginResponse struct {
+	Found   bool   `json:"found"`
+	File    string `json:"file"`
+	Version string `json:"version"`
+	Error   string `json:"error"`
+}
+
+// NewPluginSource creates a source backed by command. file is an optional
+// hint passed along with detect requests, typically set from the config file.
+func NewPluginSource(runner external.CommandRunner, command, file string) VersionSource {
+	name := strings.TrimSuffix(filepath.Base(command), filepath.Ext(command))
+	return &PluginSource{
+		runner:  runner,
+		name:    strings.TrimPrefix(name, PluginPrefix),
+		command: command,
+		file:    file,
+	}
+}
+
+// DiscoverPlugins returns a source for every bumpr-source-* executable on
+// PATH, sorted by name. As with command lookup, the first directory wins.
+func DiscoverPlugins(runner external.CommandRunner) []VersionSource {
+	seen := map[string]bool{}
+	var plugins []VersionSource
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(dir, PluginPrefix+"*"))
+		sort.Strings(matches)
+
+		for _, path := range matches {
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() || !isExecutable(path, info) {
+				continue
+			}
+
+			plugin := NewPluginSource(runner, path, "")
+			if seen[plugin.Name()] {
+				continue
+			}
+			seen[plugin.Name()] = true
+			plugins = append(plugins, plugin)
+		}
+	}
+
+	sort.SliceStable(plugins, func(i, j int) bool {
+		return plugins[i].Name() < plugins[j].Name()
+	})
+	return plugins
+}
+
+func isExecutable(path string, info os.FileInfo) bool {
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(path))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	}
+	return info.Mode()&0111 != 0
+}
+
+func (p *PluginSource) Name() string {
+	return p.name
+}
+
+func (p *PluginSource) GetDefaultFileName() string {
+	return p.file
+}
+
+func (p *PluginSource) Detect(projectPath string) bool {
+	_, err := p.Locate(projectPath)
+	return err == nil
+}
+
+func (p *PluginSource) Locate(projectPath string) (string, error) {
+	response, err := p.call(pluginRequest{Command: "detect", Project: projectPath, File: p.file})
+	if err != nil {
+		return "", err
+	}
+	if !response.Found || response.File == "" {
+		return "", fmt.Errorf("plugin %s found no version source", p.name)
+	}
+
+	if filepath.IsAbs(response.File) {
+		return response.File, nil
+	}
+	return filepath.Join(projectPath, response.File), nil
+}
+
+func (p *PluginSource) GetVersion(filePath string) (string, error) {
+	response, err := p.call(pluginRequest{Command: "get", File: filePath})
+	if err != nil {
+		return "", err
+	}
+	if response.Version == "" {
+		return "", fmt.Errorf("plugin %s returned no version", p.name)
+	}
+
+	return response.Version, nil
+}
+
+func (p *PluginSource) SetVersion(filePath string, newVersion string) error {
+	_, err := p.call(pluginRequest{Command: "set", File: filePath, Version: newVersion})
+	return err
+}
+
+func (p *PluginSource) call(request pluginRequest) (*pluginResponse, error) {
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	result, runErr := p.runner.RunWithInput(context.Background(), string(input), p.command)
+
+	var response pluginResponse
+	if result != nil && strings.TrimSpace(result.Stdout) != "" {
+		if err := json.Unmarshal([]byte(result.Stdout), &response); err != nil && runErr == nil {
+			return nil, fmt.Errorf("plugin %s returned invalid JSON for %s: %w", p.name, request.Command, err)
+		}
+	}
+
+	if response.Error != "" {
+		return nil, fmt.Errorf("plugin %s failed to %s: %s", p.name, request.Command, response.Error)
+	}
+	if runErr != nil {
+		if result != nil && strings.TrimSpace(result.Stderr) != "" {
+			return nil, fmt.Errorf("plugin %s failed to %s: %s", p.name, request.Command, strings.TrimSpace(result.Stderr))
+		}
+		return nil, fmt.Errorf("plugin %s failed to %s: %w", p.name, request.Command, runErr)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,160 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/oriol/bumpr/internal/external"
+)
+
+// The test plugin keeps its version in a "release" file and answers with sed
+// instead of a JSON parser to stay dependency free.
+const testPlugin = `#!/bin/sh
+request=$(cat)
+case "$request" in
+*'"command":"detect"'*)
+	if [ -f release ]; then echo '{"found": true, "file": "release"}'; else echo '{"found": false}'; fi ;;
+*'"command":"get"'*)
+	file=$(echo "$request" | sed 's/.*"file":"\([^"]*\)".*/\1/')
+	printf '{"version": "%s"}\n' "$(cat "$file")" ;;
+*'"command":"set"'*)
+	file=$(echo "$request" | sed 's/.*"file":"\([^"]*\)".*/\1/')
+	version=$(echo "$request" | sed 's/.*"version":"\([^"]*\)".*/\1/')
+	case "$version" in
+	*bad*) echo '{"error": "refusing bad version"}' ;;
+	*) printf '%s' "$version" > "$file"; echo '{}' ;;
+	esac ;;
+*)
+	echo "unknown request" >&2; exit 1 ;;
+esac
+`
+
+func writePlugin(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to create plugin: %v", err)
+	}
+	return path
+}
+
+func TestPluginSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test plugin is a shell script")
+	}
+
+	projectDir := t.TempDir()
+	command := writePlugin(t, t.TempDir(), "bumpr-source-release", testPlugin)
+	source := NewPluginSource(external.NewRunnerInDir(projectDir, false), command, "")
+
+	if source.Name() != "release" {
+		t.Errorf("Name() = %q, want %q", source.Name(), "release")
+	}
+	if source.Detect(projectDir) {
+		t.Fatal("Detect() = true without a release file")
+	}
+
+	if err := os.WriteFile(filepath.Join(projectDir, "release"), []byte("1.4.0"), 0644); err != nil {
+		t.Fatalf("failed to create release file: %v", err)
+	}
+
+	file, err := locateSourceFile(source, projectDir)
+	if err != nil {
+		t.Fatalf("Locate() error = %v", err)
+	}
+	if want := filepath.Join(projectDir, "release"); file != want {
+		t.Errorf("Locate() = %q, want %q", file, want)
+	}
+
+	got, err := source.GetVersion(file)
+	if err != nil || got != "1.4.0" {
+		t.Fatalf("GetVersion() = %q, %v, want 1.4.0", got, err)
+	}
+
+	if err := source.SetVersion(file, "1.5.0"); err != nil {
+		t.Fatalf("SetVersion() error = %v", err)
+	}
+	content, _ := os.ReadFile(file)
+	if string(content) != "1.5.0" {
+		t.Errorf("release file = %q, want %q", content, "1.5.0")
+	}
+
+	err = source.SetVersion(file, "1.6.0-bad")
+	if err == nil || !strings.Contains(err.Error(), "refusing bad version") {
+		t.Errorf("SetVersion() error = %v, want the plugin's error message", err)
+	}
+}
+
+func TestPluginSource_Failures(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test plugins are shell scripts")
+	}
+
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+	}{
+		{
+			name:    "non-zero exit reports stderr",
+			script:  "#!/bin/sh\necho 'cannot parse manifest' >&2\nexit 3\n",
+			wantErr: "cannot parse manifest",
+		},
+		{
+			name:    "invalid JSON",
+			script:  "#!/bin/sh\necho 'version 1.0.0'\n",
+			wantErr: "invalid JSON",
+		},
+		{
+			name:    "missing version",
+			script:  "#!/bin/sh\necho '{}'\n",
+			wantErr: "returned no version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command := writePlugin(t, t.TempDir(), "bumpr-source-broken", tt.script)
+			source := NewPluginSource(external.NewRunner(false), command, "")
+
+			_, err := source.GetVersion("manifest")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("GetVersion() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiscoverPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("discovery test relies on the executable bit")
+	}
+
+	first := t.TempDir()
+	second := t.TempDir()
+	writePlugin(t, first, "bumpr-source-zeta", testPlugin)
+	writePlugin(t, first, "bumpr-source-alpha", testPlugin)
+	writePlugin(t, second, "bumpr-source-alpha", testPlugin)
+	writePlugin(t, second, "bumpr-source-beta", testPlugin)
+	if err := os.WriteFile(filepath.Join(second, "bumpr-source-data"), []byte("not executable"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second)
+
+	plugins := DiscoverPlugins(external.NewRunner(false))
+	var names []string
+	for _, plugin := range plugins {
+		names = append(names, plugin.Name())
+	}
+	if got := strings.Join(names, ","); got != "alpha,beta,zeta" {
+		t.Errorf("DiscoverPlugins() = %s, want alpha,beta,zeta", got)
+	}
+
+	if command := plugins[0].(*PluginSource).command; command != filepath.Join(first, "bumpr-source-alpha") {
+		t.Errorf("alpha resolved to %s, want the first PATH entry", command)
+	}
+}
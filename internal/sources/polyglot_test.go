@@ -0,0 +1,160 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPolyglotSources(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     VersionSource
+		file       string
+		content    string
+		want       string
+		newVersion string
+		wantAfter  string
+	}{
+		{
+			name:   "ruby version constant",
+			source: NewRubyGemSource(),
+			file:   "lib/my_gem/version.rb",
+			content: `# frozen_string_literal: true
+
+module MyGem
+  VERSION = "0.3.1".freeze
+end
+`,
+			want:       "0.3.1",
+			newVersion: "0.4.0",
+			wantAfter: `# frozen_string_literal: true
+
+module MyGem
+  VERSION = "0.4.0".freeze
+end
+`,
+		},
+		{
+			name:   "composer keeps formatting",
+			source: NewComposerSource(),
+			file:   "composer.json",
+			content: `{
+    "name": "acme/lib",
+    "version": "1.0.0",
+    "require": {"php": ">=8.1"}
+}
+`,
+			want:       "1.0.0",
+			newVersion: "1.0.1",
+			wantAfter: `{
+    "name": "acme/lib",
+    "version": "1.0.1",
+    "require": {"php": ">=8.1"}
+}
+`,
+		},
+		{
+			name:       "pubspec increments build number",
+			source:     NewPubspecSource(),
+			file:       "pubspec.yaml",
+			content:    "name: app\nversion: 1.2.3+45 # store build\nenvironment:\n  sdk: '>=3.0.0 <4.0.0'\n",
+			want:       "1.2.3",
+			newVersion: "1.3.0",
+			wantAfter:  "name: app\nversion: 1.3.0+46 # store build\nenvironment:\n  sdk: '>=3.0.0 <4.0.0'\n",
+		},
+		{
+			name:       "pubspec without build number",
+			source:     NewPubspecSource(),
+			file:       "pubspec.yaml",
+			content:    "name: pkg\nversion: \"0.1.0\"\n",
+			want:       "0.1.0",
+			newVersion: "0.1.1",
+			wantAfter:  "name: pkg\nversion: \"0.1.1\"\n",
+		},
+		{
+			name:   "mix module attribute",
+			source: NewMixSource(),
+			file:   "mix.exs",
+			content: `defmodule App.MixProject do
+  use Mix.Project
+
+  @version "2.1.0"
+
+  def project do
+    [app: :app, version: @version, deps: [{:jason, "~> 1.4"}]]
+  end
+end
+`,
+			want:       "2.1.0",
+			newVersion: "2.2.0",
+			wantAfter: `defmodule App.MixProject do
+  use Mix.Project
+
+  @version "2.2.0"
+
+  def project do
+    [app: :app, version: @version, deps: [{:jason, "~> 1.4"}]]
+  end
+end
+`,
+		},
+		{
+			name:       "mix inline version",
+			source:     NewMixSource(),
+			file:       "mix.exs",
+			content:    "  def project do\n    [app: :app, version: \"0.0.1\"]\n  end\n",
+			want:       "0.0.1",
+			newVersion: "0.0.2",
+			wantAfter:  "  def project do\n    [app: :app, version: \"0.0.2\"]\n  end\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			tmpFile := filepath.Join(tmpDir, tt.file)
+			if err := os.MkdirAll(filepath.Dir(tmpFile), 0755); err != nil {
+				t.Fatalf("failed to create dir: %v", err)
+			}
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create temp file: %v", err)
+			}
+
+			if !tt.source.Detect(tmpDir) {
+				t.Errorf("Detect() = false, want true")
+			}
+
+			got, err := tt.source.GetVersion(tmpFile)
+			if err != nil {
+				t.Fatalf("GetVersion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetVersion() = %v, want %v", got, tt.want)
+			}
+
+			if err := tt.source.SetVersion(tmpFile, tt.newVersion); err != nil {
+				t.Fatalf("SetVersion() error = %v", err)
+			}
+
+			content, err := os.ReadFile(tmpFile)
+			if err != nil {
+				t.Fatalf("failed to read updated file: %v", err)
+			}
+			if string(content) != tt.wantAfter {
+				t.Errorf("SetVersion() content =\n%s\nwant:\n%s", content, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestComposerSource_DetectWithoutVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "composer.json"), []byte(`{"name": "acme/app"}`), 0644); err != nil {
+		t.Fatalf("failed to create composer.json: %v", err)
+	}
+
+	if NewComposerSource().Detect(tmpDir) {
+		t.Error("Detect() = true, want false when composer.json has no version")
+	}
+}
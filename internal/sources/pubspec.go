@@ -0,0 +1,88 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+)
+
+// PubspecSource handles Dart/Flutter pubspec.yaml files. The build number
+// after "+" is not part of the version bumpr computes with; it is incremented
+// on every release instead.
+type PubspecSource struct{}
+
+func NewPubspecSource() VersionSource {
+	return &PubspecSource{}
+}
+
+var pubspecVersionLine = regexp.MustCompile(`(?m)^(version:[ \t]*)(["']?)([^\s"'+#]+)(?:\+(\d+))?(["']?)`)
+
+func (p *PubspecSource) Name() string {
+	return "pubspec.yaml"
+}
+
+func (p *PubspecSource) GetDefaultFileName() string {
+	return "pubspec.yaml"
+}
+
+func (p *PubspecSource) Detect(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, "pubspec.yaml"))
+	return err == nil
+}
+
+func (p *PubspecSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	matches := pubspecVersionLine.FindSubmatch(content)
+	if matches == nil {
+		return "", fmt.Errorf("version field not found in pubspec.yaml")
+	}
+
+	return string(matches[3]), nil
+}
+
+func (p *PubspecSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	indexes := pubspecVersionLine.FindSubmatchIndex(content)
+	if indexes == nil {
+		return fmt.Errorf("could not find version pattern to replace")
+	}
+
+	group := func(i int) string {
+		if indexes[2*i] < 0 {
+			return ""
+		}
+		return string(content[indexes[2*i]:indexes[2*i+1]])
+	}
+
+	value := newVersion
+	if build := group(4); build != "" {
+		number, err := strconv.Atoi(build)
+		if err != nil {
+			return fmt.Errorf("invalid build number %q: %w", build, err)
+		}
+		value = fmt.Sprintf("%s+%d", newVersion, number+1)
+	}
+
+	replacement := group(1) + group(2) + value + group(5)
+
+	output := make([]byte, 0, len(content)+len(replacement))
+	output = append(output, content[:indexes[0]]...)
+	output = append(output, replacement...)
+	output = append(output, content[indexes[1]:]...)
+
+	if err := os.WriteFile(filePath, output, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
@@ -5,11 +5,47 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
+	"github.com/oriol/bumpr/internal/external"
+	"github.com/oriol/bumpr/internal/version"
 	"github.com/pelletier/go-toml/v2"
 )
 
-type PyProjectSource struct{}
+// Default tag_regex of setuptools_scm, also used by hatch-vcs
+const scmDefaultTagRegex = `^(?:[\w-]+-)?(?P<version>[vV]?\d+(?:\.\d+){0,2}[^\+]*)(?:\+.*)?$`
+
+type PyProjectSource struct {
+	git external.GitBackend
+}
+
+// scmConfig is the part of a pyproject.toml that decides whether the version
+// is derived from git tags by setuptools_scm or hatch-vcs.
+type scmConfig struct {
+	BuildSystem struct {
+		Requires []string `toml:"requires"`
+	} `toml:"build-system"`
+	Project struct {
+		Dynamic []string `toml:"dynamic"`
+	} `toml:"project"`
+	Tool struct {
+		SetuptoolsSCM *struct {
+			TagRegex        string `toml:"tag_regex"`
+			FallbackVersion string `toml:"fallback_version"`
+		} `toml:"setuptools_scm"`
+		Hatch struct {
+			Version struct {
+				Source          string `toml:"source"`
+				TagPattern      string `toml:"tag-pattern"`
+				FallbackVersion string `toml:"fallback-version"`
+				RawOptions      struct {
+					TagRegex        string `toml:"tag_regex"`
+					FallbackVersion string `toml:"fallback_version"`
+				} `toml:"raw-options"`
+			} `toml:"version"`
+		} `toml:"hatch"`
+	} `toml:"tool"`
+}
 
 func NewPyProjectSource() VersionSource {
 	return &PyProjectSource{}
@@ -28,7 +64,22 @@ func (p *PyProjectSource) Detect(projectPath string) bool {
 	return err == nil
 }
 
+func (p *PyProjectSource) SetGit(git external.GitBackend) {
+	p.git = git
+}
+
+// IsTagOnly reports whether the version is dynamic and provided by
+// setuptools_scm or hatch-vcs, in which case it lives in git tags alone.
+func (p *PyProjectSource) IsTagOnly(filePath string) bool {
+	_, _, ok := p.scmSettings(filePath)
+	return ok
+}
+
 func (p *PyProjectSource) GetVersion(filePath string) (string, error) {
+	if tagRegex, fallback, ok := p.scmSettings(filePath); ok {
+		return p.scmVersion(filePath, tagRegex, fallback)
+	}
+
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read file: %w", err)
@@ -79,6 +130,11 @@ func (p *PyProjectSource) GetVersion(filePath string) (string, error) {
 }
 
 func (p *PyProjectSource) SetVersion(filePath string, newVersion string) error {
+	// Dynamic versions are written by creating the tag, the file stays as is
+	if p.IsTagOnly(filePath) {
+		return nil
+	}
+
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read file: %w", err)
@@ -121,4 +177,143 @@ func (p *PyProjectSource) SetVersion(filePath string, newVersion string) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// scmSettings returns the tag regex and fallback version of a setuptools_scm or
+// hatch-vcs managed version. ok is false when the version is not dynamic or
+// comes from another plugin.
+func (p *PyProjectSource) scmSettings(filePath string) (tagRegex, fallback string, ok bool) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", "", false
+	}
+
+	var config scmConfig
+	if err := toml.Unmarshal(content, &config); err != nil {
+		return "", "", false
+	}
+
+	dynamic := false
+	for _, field := range config.Project.Dynamic {
+		if field == "version" {
+			dynamic = true
+		}
+	}
+	if !dynamic {
+		return "", "", false
+	}
+
+	if hatch := config.Tool.Hatch.Version; hatch.Source == "vcs" {
+		tagRegex = firstNonEmpty(hatch.TagPattern, hatch.RawOptions.TagRegex, scmDefaultTagRegex)
+		fallback = firstNonEmpty(hatch.FallbackVersion, hatch.RawOptions.FallbackVersion)
+		return tagRegex, fallback, true
+	}
+
+	scm := config.Tool.SetuptoolsSCM
+	if scm == nil {
+		// setuptools_scm >= 8 also works from build requirements alone
+		for _, requirement := range config.BuildSystem.Requires {
+			name := strings.ToLower(strings.ReplaceAll(requirement, "_", "-"))
+			if strings.HasPrefix(name, "setuptools-scm") {
+				return scmDefaultTagRegex, "", true
+			}
+		}
+		return "", "", false
+	}
+
+	return firstNonEmpty(scm.TagRegex, scmDefaultTagRegex), scm.FallbackVersion, true
+}
+
+// scmVersion returns the highest version extracted with tagRegex from the tags
+// reachable from HEAD, or the fallback version when there is none.
+func (p *PyProjectSource) scmVersion(filePath, tagRegex, fallback string) (string, error) {
+	re, err := regexp.Compile(tagRegex)
+	if err != nil {
+		return "", fmt.Errorf("unsupported tag_regex %q: %w", tagRegex, err)
+	}
+
+	var tags []string
+	err = fmt.Errorf("no git repository to read tags from")
+	if p.git != nil {
+		tags, err = p.git.MergedTags("")
+	}
+	if err != nil && fallback == "" {
+		return "", fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	var latest *version.Version
+	latestStr := ""
+	for _, tag := range tags {
+		candidate, ok := scmTagVersion(re, tag)
+		if !ok {
+			continue
+		}
+
+		v, err := version.Parse(candidate)
+		if err != nil {
+			continue
+		}
+		if latest == nil || v.Compare(latest) > 0 {
+			latest = v
+			latestStr = candidate
+		}
+	}
+
+	if latest == nil {
+		if fallback != "" {
+			return fallback, nil
+		}
+		return "", fmt.Errorf("version is dynamic but no tag reachable from HEAD matches %s", tagRegex)
+	}
+
+	return latestStr, nil
+}
+
+// ValidateTag refuses a release tag that tag_regex does not read newVersion
+// from, since the build would then silently keep the previous version.
+func (p *PyProjectSource) ValidateTag(filePath, tagName, newVersion string) error {
+	tagRegex, _, ok := p.scmSettings(filePath)
+	if !ok {
+		return nil
+	}
+	re, err := regexp.Compile(tagRegex)
+	if err != nil {
+		return fmt.Errorf("unsupported tag_regex %q: %w", tagRegex, err)
+	}
+
+	candidate, ok := scmTagVersion(re, tagName)
+	if ok {
+		tagged, err := version.Parse(candidate)
+		next, nextErr := version.Parse(newVersion)
+		if err == nil && nextErr == nil && tagged.Compare(next) == 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("tag %s does not carry version %s according to tag_regex %s, so the build would not pick it up. Set tag_prefix to match it", tagName, newVersion, tagRegex)
+}
+
+// scmTagVersion extracts the version from tag like setuptools_scm: the
+// "version" group, else the first group, else the whole match.
+func scmTagVersion(re *regexp.Regexp, tag string) (string, bool) {
+	matches := re.FindStringSubmatch(tag)
+	if matches == nil {
+		return "", false
+	}
+
+	if index := re.SubexpIndex("version"); index > 0 {
+		return matches[index], true
+	}
+	if len(matches) > 1 {
+		return matches[1], true
+	}
+	return matches[0], true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,231 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oriol/bumpr/internal/external"
+)
+
+func TestPyProjectSource_DynamicVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		tags    []string
+		tagOnly bool
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "setuptools_scm table",
+			content: `[build-system]
+requires = ["setuptools>=64", "setuptools_scm>=8"]
+
+[project]
+name = "demo"
+dynamic = ["version"]
+
+[tool.setuptools_scm]
+`,
+			tags:    []string{"v1.2.0", "v1.10.0", "nightly"},
+			tagOnly: true,
+			want:    "v1.10.0",
+		},
+		{
+			name: "setuptools_scm build requirement only",
+			content: `[build-system]
+requires = ["setuptools", "setuptools-scm"]
+
+[project]
+dynamic = ["version", "readme"]
+`,
+			tags:    []string{"0.3.0"},
+			tagOnly: true,
+			want:    "0.3.0",
+		},
+		{
+			name: "setuptools_scm tag_regex",
+			content: `[project]
+dynamic = ["version"]
+
+[tool.setuptools_scm]
+tag_regex = '^release/(?P<version>\d+\.\d+\.\d+)$'
+`,
+			tags:    []string{"release/2.0.0", "3.0.0", "release/2.1.0"},
+			tagOnly: true,
+			want:    "2.1.0",
+		},
+		{
+			name: "setuptools_scm fallback version",
+			content: `[project]
+dynamic = ["version"]
+
+[tool.setuptools_scm]
+fallback_version = "0.0.1"
+`,
+			tagOnly: true,
+			want:    "0.0.1",
+		},
+		{
+			name: "hatch-vcs with tag-pattern",
+			content: `[project]
+dynamic = ["version"]
+
+[tool.hatch.version]
+source = "vcs"
+tag-pattern = '^pkg-v(?P<version>.+)$'
+`,
+			tags:    []string{"pkg-v1.0.0", "v9.0.0", "pkg-v1.1.0"},
+			tagOnly: true,
+			want:    "1.1.0",
+		},
+		{
+			name: "hatch-vcs raw-options fallback",
+			content: `[project]
+dynamic = ["version"]
+
+[tool.hatch.version]
+source = "vcs"
+raw-options = { fallback_version = "0.1.0" }
+`,
+			tagOnly: true,
+			want:    "0.1.0",
+		},
+		{
+			name: "dynamic version without tags or fallback",
+			content: `[project]
+dynamic = ["version"]
+
+[tool.setuptools_scm]
+`,
+			tagOnly: true,
+			wantErr: true,
+		},
+		{
+			name: "setuptools_scm configured but version is static",
+			content: `[project]
+version = "4.5.6"
+
+[tool.setuptools_scm]
+`,
+			tags: []string{"v9.9.9"},
+			want: "4.5.6",
+		},
+		{
+			name: "dynamic version from another backend",
+			content: `[project]
+dynamic = ["version"]
+
+[tool.hatch.version]
+path = "src/demo/__about__.py"
+
+[tool.demo]
+version = "7.0.0"
+`,
+			want: "7.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "pyproject.toml")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create pyproject.toml: %v", err)
+			}
+
+			source := NewPyProjectSource().(*PyProjectSource)
+			source.SetGit(external.NewGitCommands(&tagListRunner{tags: tt.tags}, false))
+
+			if got := source.IsTagOnly(filePath); got != tt.tagOnly {
+				t.Errorf("IsTagOnly() = %v, want %v", got, tt.tagOnly)
+			}
+
+			got, err := source.GetVersion(filePath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPyProjectSource_DynamicVersionIsNotRewritten(t *testing.T) {
+	content := `[project]
+name = "demo"
+dynamic = ["version"]
+
+[tool.setuptools_scm]
+version_file = "src/demo/_version.py"
+
+[tool.demo.build]
+version = "unrelated"
+`
+	filePath := filepath.Join(t.TempDir(), "pyproject.toml")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create pyproject.toml: %v", err)
+	}
+
+	source := NewPyProjectSource()
+	if err := source.SetVersion(filePath, "1.0.1"); err != nil {
+		t.Fatalf("SetVersion() error = %v", err)
+	}
+
+	written, _ := os.ReadFile(filePath)
+	if string(written) != content {
+		t.Errorf("SetVersion() changed the file:\n%s", written)
+	}
+}
+
+func TestPyProjectSource_ValidateTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		tag     string
+		wantErr bool
+	}{
+		{
+			name:    "default tag_regex reads a v prefix",
+			content: "[project]\ndynamic = [\"version\"]\n\n[tool.setuptools_scm]\n",
+			tag:     "v1.2.4",
+		},
+		{
+			name:    "custom tag_regex matches the tag",
+			content: "[project]\ndynamic = [\"version\"]\n\n[tool.setuptools_scm]\ntag_regex = \"^release-(?P<version>.*)$\"\n",
+			tag:     "release-1.2.4",
+		},
+		{
+			name:    "custom tag_regex does not match the tag",
+			content: "[project]\ndynamic = [\"version\"]\n\n[tool.setuptools_scm]\ntag_regex = \"^release-(?P<version>.*)$\"\n",
+			tag:     "v1.2.4",
+			wantErr: true,
+		},
+		{
+			name:    "hatch-vcs tag_pattern reads another version",
+			content: "[project]\ndynamic = [\"version\"]\n\n[tool.hatch.version]\nsource = \"vcs\"\ntag-pattern = \"^app-(?P<version>.*)$\"\n",
+			tag:     "app-1.2.40",
+			wantErr: true,
+		},
+		{
+			name:    "static version ignores tags",
+			content: "[project]\nversion = \"1.2.3\"\n",
+			tag:     "anything",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "pyproject.toml")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create pyproject.toml: %v", err)
+			}
+
+			err := NewPyProjectSource().(*PyProjectSource).ValidateTag(filePath, tt.tag, "1.2.4")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTag(%s) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCondaRecipeSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		updated string
+	}{
+		{
+			name: "jinja variable",
+			content: `{% set name = "demo" %}
+{% set version = "1.2.3" %}
+{% set build_number = 4 %}
+
+package:
+  name: {{ name|lower }}
+  version: {{ version }}
+
+source:
+  url: https://example.com/{{ name }}-{{ version }}.tar.gz
+  sha256: abc123
+
+build:
+  number: {{ build_number }}
+`,
+			want: "1.2.3",
+			updated: `{% set name = "demo" %}
+{% set version = "1.3.0" %}
+{% set build_number = 0 %}
+
+package:
+  name: {{ name|lower }}
+  version: {{ version }}
+
+source:
+  url: https://example.com/{{ name }}-{{ version }}.tar.gz
+  sha256: abc123
+
+build:
+  number: {{ build_number }}
+`,
+		},
+		{
+			name: "literal package version",
+			content: `package:
+  name: demo
+  version: "0.9.1"  # keep in sync
+
+build:
+  number: 2
+  noarch: python
+`,
+			want: "0.9.1",
+			updated: `package:
+  name: demo
+  version: "1.3.0"  # keep in sync
+
+build:
+  number: 0
+  noarch: python
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := writeProjectFiles(t, map[string]string{"recipe/meta.yaml": tt.content})
+			source := NewCondaRecipeSource()
+
+			file, err := locateSourceFile(source, projectDir)
+			if err != nil {
+				t.Fatalf("Locate() error = %v", err)
+			}
+			if want := filepath.Join(projectDir, "recipe", "meta.yaml"); file != want {
+				t.Errorf("Locate() = %v, want %v", file, want)
+			}
+
+			got, err := source.GetVersion(file)
+			if err != nil || got != tt.want {
+				t.Fatalf("GetVersion() = %q, %v, want %q", got, err, tt.want)
+			}
+
+			if err := source.SetVersion(file, "1.3.0"); err != nil {
+				t.Fatalf("SetVersion() error = %v", err)
+			}
+			written, _ := os.ReadFile(file)
+			if string(written) != tt.updated {
+				t.Errorf("SetVersion() wrote:\n%s\nwant:\n%s", written, tt.updated)
+			}
+		})
+	}
+}
+
+func TestNixSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		file    string
+		want    string
+		updated string
+	}{
+		{
+			name: "flake with a derivation",
+			files: map[string]string{"flake.nix": `{
+  outputs = { self, nixpkgs }: {
+    packages.x86_64-linux.default = nixpkgs.legacyPackages.x86_64-linux.buildGoModule {
+      pname = "demo";
+      version = "2.4.0";
+      src = ./.;
+    };
+  };
+}
+`},
+			file: "flake.nix",
+			want: "2.4.0",
+			updated: `{
+  outputs = { self, nixpkgs }: {
+    packages.x86_64-linux.default = nixpkgs.legacyPackages.x86_64-linux.buildGoModule {
+      pname = "demo";
+      version = "2.5.0";
+      src = ./.;
+    };
+  };
+}
+`,
+		},
+		{
+			name: "interpolated version is skipped",
+			files: map[string]string{
+				"default.nix":     "{ pkgs }: pkgs.hello\n",
+				"nix/package.nix": "{ stdenv }:\nlet base = \"1\"; in\nstdenv.mkDerivation rec {\n  version = \"${base}.0.0\";\n  passthru.version = \"1.9.9\";\n}\n",
+			},
+			file:    "nix/package.nix",
+			want:    "1.9.9",
+			updated: "{ stdenv }:\nlet base = \"1\"; in\nstdenv.mkDerivation rec {\n  version = \"${base}.0.0\";\n  passthru.version = \"2.5.0\";\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := writeProjectFiles(t, tt.files)
+			source := NewNixSource()
+
+			file, err := locateSourceFile(source, projectDir)
+			if err != nil {
+				t.Fatalf("Locate() error = %v", err)
+			}
+			if want := filepath.Join(projectDir, tt.file); file != want {
+				t.Errorf("Locate() = %v, want %v", file, want)
+			}
+
+			got, err := source.GetVersion(file)
+			if err != nil || got != tt.want {
+				t.Fatalf("GetVersion() = %q, %v, want %q", got, err, tt.want)
+			}
+
+			if err := source.SetVersion(file, "2.5.0"); err != nil {
+				t.Fatalf("SetVersion() error = %v", err)
+			}
+			written, _ := os.ReadFile(file)
+			if string(written) != tt.updated {
+				t.Errorf("SetVersion() wrote:\n%s\nwant:\n%s", written, tt.updated)
+			}
+		})
+	}
+}
+
+func TestDetector_CondaAndNix(t *testing.T) {
+	projectDir := writeProjectFiles(t, map[string]string{
+		"conda.recipe/meta.yaml": "{% set version = \"3.1.0\" %}\npackage:\n  version: {{ version }}\n",
+		"default.nix":            "{ stdenv }: stdenv.mkDerivation { pname = \"demo\"; version = \"3.1.0\"; }\n",
+	})
+
+	detector := NewDetector()
+	candidates := detector.DetectAll(projectDir)
+	if len(candidates) != 2 {
+		t.Fatalf("DetectAll() found %d sources, want 2", len(candidates))
+	}
+
+	source, err := detector.GetSourceByFile(filepath.Join(projectDir, "conda.recipe", "meta.yaml"))
+	if err != nil || source.Name() != NewCondaRecipeSource().Name() {
+		t.Errorf("GetSourceByFile(meta.yaml) = %v, %v", source, err)
+	}
+}
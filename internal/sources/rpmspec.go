@@ -0,0 +1,148 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RPMSpecSource updates Version: in a .spec file, resets Release: and
+// prepends a %changelog entry for the new version.
+type RPMSpecSource struct {
+	changes []string
+	now     func() time.Time
+}
+
+func NewRPMSpecSource() VersionSource {
+	return &RPMSpecSource{now: time.Now}
+}
+
+var (
+	rpmVersionTag   = regexp.MustCompile(`(?mi)^(Version:[ \t]*)(\S+)`)
+	rpmReleaseTag   = regexp.MustCompile(`(?mi)^(Release:[ \t]*)(\d+)(\S*)`)
+	rpmPackagerTag  = regexp.MustCompile(`(?mi)^Packager:[ \t]*(.+?)\s*$`)
+	rpmChangelog    = regexp.MustCompile(`(?m)^%changelog[ \t]*\n`)
+	rpmChangeAuthor = regexp.MustCompile(`(?m)^\* \w{3} \w{3} +\d+ \d{4} (.+?)(?: - \S+)?\s*$`)
+)
+
+func (r *RPMSpecSource) Name() string {
+	return "*.spec"
+}
+
+func (r *RPMSpecSource) GetDefaultFileName() string {
+	return "package.spec"
+}
+
+func (r *RPMSpecSource) Detect(projectPath string) bool {
+	_, err := r.Locate(projectPath)
+	return err == nil
+}
+
+func (r *RPMSpecSource) Locate(projectPath string) (string, error) {
+	var candidates []string
+	for _, pattern := range []string{"*.spec", "*/*.spec"} {
+		matches, err := filepath.Glob(filepath.Join(projectPath, pattern))
+		if err != nil {
+			return "", err
+		}
+		sort.Strings(matches)
+		candidates = append(candidates, matches...)
+	}
+
+	for _, candidate := range candidates {
+		content, err := os.ReadFile(candidate)
+		if err == nil && rpmVersionTag.Match(content) {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("no .spec file with a Version: tag found")
+}
+
+func (r *RPMSpecSource) SetChanges(changes []string) {
+	r.changes = changes
+}
+
+func (r *RPMSpecSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	matches := rpmVersionTag.FindSubmatch(content)
+	if matches == nil {
+		return "", fmt.Errorf("Version: tag not found in %s", filepath.Base(filePath))
+	}
+
+	return string(matches[2]), nil
+}
+
+func (r *RPMSpecSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	contentStr := string(content)
+	if !rpmVersionTag.MatchString(contentStr) {
+		return fmt.Errorf("could not find version pattern to replace")
+	}
+
+	// RPM versions cannot contain dashes and never carry the v prefix
+	rpmVersion := strings.ReplaceAll(strings.TrimPrefix(newVersion, "v"), "-", "~")
+	contentStr = replaceFirst(rpmVersionTag, contentStr, "${1}"+rpmVersion)
+
+	// A new version restarts the package release, keeping suffixes like %{?dist}
+	contentStr = replaceFirst(rpmReleaseTag, contentStr, "${1}1${3}")
+
+	if loc := rpmChangelog.FindStringIndex(contentStr); loc != nil {
+		entry := r.changelogEntry(contentStr, rpmVersion+"-1")
+		contentStr = contentStr[:loc[1]] + entry + contentStr[loc[1]:]
+	}
+
+	if err := os.WriteFile(filePath, []byte(contentStr), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RPMSpecSource) changelogEntry(content, versionRelease string) string {
+	packager := os.Getenv("RPM_PACKAGER")
+	if packager == "" {
+		if matches := rpmPackagerTag.FindStringSubmatch(content); matches != nil {
+			packager = matches[1]
+		} else if matches := rpmChangeAuthor.FindStringSubmatch(content); matches != nil {
+			packager = matches[1]
+		} else {
+			packager = "bumpr"
+		}
+	}
+
+	changes := r.changes
+	if len(changes) == 0 {
+		changes = []string{"Update to " + versionRelease}
+	}
+
+	var entry strings.Builder
+	fmt.Fprintf(&entry, "* %s %s - %s\n", r.now().Format("Mon Jan 02 2006"), packager, versionRelease)
+	for _, change := range changes {
+		fmt.Fprintf(&entry, "- %s\n", change)
+	}
+	entry.WriteString("\n")
+
+	return entry.String()
+}
+
+func replaceFirst(re *regexp.Regexp, s, template string) string {
+	loc := re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return s
+	}
+	expanded := re.ExpandString(nil, template, s, loc)
+	return s[:loc[0]] + string(expanded) + s[loc[1]:]
+}
@@ -0,0 +1,90 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+type RubyGemSource struct{}
+
+func NewRubyGemSource() VersionSource {
+	return &RubyGemSource{}
+}
+
+var rubyVersionConst = regexp.MustCompile(`(?m)^(\s*VERSION\s*=\s*)(["'])([^"']+)(["'])`)
+
+func (r *RubyGemSource) Name() string {
+	return "lib/*/version.rb"
+}
+
+func (r *RubyGemSource) GetDefaultFileName() string {
+	return "version.rb"
+}
+
+func (r *RubyGemSource) Detect(projectPath string) bool {
+	_, err := r.Locate(projectPath)
+	return err == nil
+}
+
+func (r *RubyGemSource) Locate(projectPath string) (string, error) {
+	// Gems keep the version in lib/<name>/version.rb, namespaced gems one level deeper
+	var candidates []string
+	for _, pattern := range []string{"lib/*/version.rb", "lib/*/*/version.rb"} {
+		matches, err := filepath.Glob(filepath.Join(projectPath, pattern))
+		if err != nil {
+			return "", err
+		}
+		sort.Strings(matches)
+		candidates = append(candidates, matches...)
+	}
+
+	for _, candidate := range candidates {
+		content, err := os.ReadFile(candidate)
+		if err == nil && rubyVersionConst.Match(content) {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("no lib/*/version.rb with a VERSION constant found")
+}
+
+func (r *RubyGemSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	matches := rubyVersionConst.FindSubmatch(content)
+	if matches == nil {
+		return "", fmt.Errorf("VERSION constant not found in %s", filepath.Base(filePath))
+	}
+
+	return string(matches[3]), nil
+}
+
+func (r *RubyGemSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Only the first VERSION constant is the gem version
+	indexes := rubyVersionConst.FindSubmatchIndex(content)
+	if indexes == nil {
+		return fmt.Errorf("could not find version pattern to replace")
+	}
+
+	output := make([]byte, 0, len(content)+len(newVersion))
+	output = append(output, content[:indexes[6]]...)
+	output = append(output, newVersion...)
+	output = append(output, content[indexes[7]:]...)
+
+	if err := os.WriteFile(filePath, output, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,455 @@
+package sources
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+	"gopkg.in/yaml.v3"
+)
+
+// StructuredSource reads and writes a string value addressed by a key path
+// (e.g. "expo.version" or "$.packages[0].version") in a JSON, YAML or TOML
+// file. Only the bytes of the value literal are rewritten, so comments, key
+// order and indentation are left untouched.
+type StructuredSource struct {
+	file   string
+	path   string
+	keys   []string
+	format string
+}
+
+// valueSpan is the location of a string literal in a document.
+type valueSpan struct {
+	start int
+	end   int
+	value string
+	quote string
+}
+
+func NewStructuredSource(file, path string) (VersionSource, error) {
+	keys, err := parseKeyPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q for %s: %w", path, file, err)
+	}
+
+	format, err := structuredFormat(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StructuredSource{file: file, path: path, keys: keys, format: format}, nil
+}
+
+func (s *StructuredSource) Name() string {
+	return s.file + ":" + s.path
+}
+
+func (s *StructuredSource) GetDefaultFileName() string {
+	return s.file
+}
+
+func (s *StructuredSource) Detect(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, s.file))
+	return err == nil
+}
+
+func (s *StructuredSource) GetVersion(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	span, err := s.locate(content)
+	if err != nil {
+		return "", err
+	}
+
+	return span.value, nil
+}
+
+func (s *StructuredSource) SetVersion(filePath string, newVersion string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	span, err := s.locate(content)
+	if err != nil {
+		return err
+	}
+
+	literal, err := s.literal(newVersion, span.quote)
+	if err != nil {
+		return err
+	}
+
+	output := make([]byte, 0, len(content)+len(literal))
+	output = append(output, content[:span.start]...)
+	output = append(output, literal...)
+	output = append(output, content[span.end:]...)
+
+	if err := os.WriteFile(filePath, output, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *StructuredSource) locate(content []byte) (*valueSpan, error) {
+	var span *valueSpan
+	var err error
+
+	switch s.format {
+	case "json":
+		span, err = locateJSONValue(content, s.keys)
+	case "yaml":
+		span, err = locateYAMLValue(content, s.keys)
+	case "toml":
+		span, err = locateTOMLValue(content, s.keys)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if span == nil {
+		return nil, fmt.Errorf("%s not found in %s", s.path, s.file)
+	}
+
+	return span, nil
+}
+
+func (s *StructuredSource) literal(value, quote string) (string, error) {
+	switch quote {
+	case `"`:
+		if s.format == "json" {
+			encoded, err := json.Marshal(value)
+			return string(encoded), err
+		}
+		return strconv.Quote(value), nil
+	case "'":
+		if strings.Contains(value, "'") {
+			return "", fmt.Errorf("cannot write %q as a single-quoted string", value)
+		}
+		return "'" + value + "'", nil
+	default:
+		return value, nil
+	}
+}
+
+func structuredFormat(file string) (string, error) {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		return "json", nil
+	case ".yml", ".yaml":
+		return "yaml", nil
+	case ".toml":
+		return "toml", nil
+	default:
+		return "", fmt.Errorf("unsupported structured file %s: expected .json, .yml, .yaml or .toml", file)
+	}
+}
+
+// parseKeyPath splits "a.b[0]['c.d']" (optionally prefixed with "$.") into keys.
+// Array indexes are kept as their decimal representation.
+func parseKeyPath(path string) ([]string, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+
+	var keys []string
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated [")
+			}
+			key := path[1:end]
+			if len(key) >= 2 && (key[0] == '\'' || key[0] == '"') && key[len(key)-1] == key[0] {
+				key = key[1 : len(key)-1]
+			} else if _, err := strconv.Atoi(key); err != nil {
+				return nil, fmt.Errorf("invalid index [%s]", key)
+			}
+			keys = append(keys, key)
+			path = path[end+1:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			keys = append(keys, path[:end])
+			path = path[end:]
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("path is empty")
+	}
+
+	return keys, nil
+}
+
+func locateJSONValue(content []byte, keys []string) (*valueSpan, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	span, err := walkJSON(decoder, content, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return span, nil
+}
+
+// walkJSON consumes exactly one value from the decoder and returns the span of
+// the string at keys within it, if any.
+func walkJSON(decoder *json.Decoder, content []byte, keys []string) (*valueSpan, error) {
+	// The decoder offset points just past the previous token; skip separators
+	start := int(decoder.InputOffset())
+	for start < len(content) && strings.IndexByte(" \t\r\n:,", content[start]) >= 0 {
+		start++
+	}
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, isDelim := token.(json.Delim)
+	if len(keys) == 0 {
+		value, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("value is not a string")
+		}
+		return &valueSpan{start: start, end: int(decoder.InputOffset()), value: value, quote: `"`}, nil
+	}
+
+	if !isDelim {
+		return nil, nil
+	}
+
+	var found *valueSpan
+	index := 0
+	for decoder.More() {
+		key := strconv.Itoa(index)
+		if delim == '{' {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ = keyToken.(string)
+		}
+		index++
+
+		if key == keys[0] && found == nil {
+			span, err := walkJSON(decoder, content, keys[1:])
+			if err != nil {
+				return nil, err
+			}
+			found = span
+			continue
+		}
+
+		var skipped json.RawMessage
+		if err := decoder.Decode(&skipped); err != nil {
+			return nil, err
+		}
+	}
+
+	// Closing delimiter
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+func locateYAMLValue(content []byte, keys []string) (*valueSpan, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	node := root.Content[0]
+	for _, key := range keys {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(node.Content) {
+				next = node.Content[index]
+			}
+		}
+		if next == nil {
+			return nil, nil
+		}
+		node = next
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return nil, fmt.Errorf("value is not a scalar")
+	}
+
+	start := yamlOffset(content, node.Line, node.Column)
+	if start < 0 {
+		return nil, fmt.Errorf("failed to locate value in YAML")
+	}
+
+	span := &valueSpan{start: start, value: node.Value}
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		span.quote = `"`
+		span.end = quotedEnd(content, start, '"', '\\')
+	case yaml.SingleQuotedStyle:
+		span.quote = "'"
+		span.end = quotedEnd(content, start, '\'', 0)
+	case 0, yaml.TaggedStyle:
+		span.end = start + len(node.Value)
+		if span.end > len(content) || string(content[start:span.end]) != node.Value {
+			return nil, fmt.Errorf("unsupported YAML scalar for %s", strings.Join(keys, "."))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported YAML scalar style for %s", strings.Join(keys, "."))
+	}
+	if span.end < 0 {
+		return nil, fmt.Errorf("unterminated YAML string")
+	}
+
+	return span, nil
+}
+
+// yamlOffset converts a 1-based line and column (counted in characters) to a byte offset.
+func yamlOffset(content []byte, line, column int) int {
+	offset := 0
+	for l := 1; l < line; l++ {
+		next := bytes.IndexByte(content[offset:], '\n')
+		if next < 0 {
+			return -1
+		}
+		offset += next + 1
+	}
+
+	for c := 1; c < column; c++ {
+		if offset >= len(content) {
+			return -1
+		}
+		_, size := utf8.DecodeRune(content[offset:])
+		offset += size
+	}
+
+	return offset
+}
+
+// quotedEnd returns the offset just past the closing quote of the string
+// starting at start. A zero escape byte means quotes are escaped by doubling.
+func quotedEnd(content []byte, start int, quote, escape byte) int {
+	for i := start + 1; i < len(content); i++ {
+		switch content[i] {
+		case escape:
+			if escape != 0 {
+				i++
+			}
+		case quote:
+			if escape == 0 && i+1 < len(content) && content[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func locateTOMLValue(content []byte, keys []string) (*valueSpan, error) {
+	target := strings.Join(keys, "\x00")
+
+	parser := unstable.Parser{}
+	parser.Reset(content)
+
+	var table []string
+	arrayTables := map[string]int{}
+
+	for parser.NextExpression() {
+		expr := parser.Expression()
+
+		switch expr.Kind {
+		case unstable.Table:
+			table = tomlKey(expr.Key())
+		case unstable.ArrayTable:
+			name := tomlKey(expr.Key())
+			joined := strings.Join(name, "\x00")
+			table = append(name, strconv.Itoa(arrayTables[joined]))
+			arrayTables[joined]++
+		case unstable.KeyValue:
+			span, err := findTOMLKeyValue(content, expr, table, target)
+			if err != nil {
+				return nil, err
+			}
+			if span != nil {
+				return span, nil
+			}
+		}
+	}
+
+	if err := parser.Error(); err != nil {
+		return nil, fmt.Errorf("failed to parse TOML: %w", err)
+	}
+
+	return nil, nil
+}
+
+func findTOMLKeyValue(content []byte, expr *unstable.Node, prefix []string, target string) (*valueSpan, error) {
+	key := append(append([]string{}, prefix...), tomlKey(expr.Key())...)
+	value := expr.Value()
+
+	switch value.Kind {
+	case unstable.String:
+		if strings.Join(key, "\x00") != target {
+			return nil, nil
+		}
+		start := int(value.Raw.Offset)
+		end := start + int(value.Raw.Length)
+		raw := string(content[start:end])
+		if strings.HasPrefix(raw, `"""`) || strings.HasPrefix(raw, "'''") {
+			return nil, fmt.Errorf("multi-line strings are not supported for %s", strings.Join(key, "."))
+		}
+		return &valueSpan{start: start, end: end, value: string(value.Data), quote: raw[:1]}, nil
+	case unstable.InlineTable:
+		children := value.Children()
+		for children.Next() {
+			span, err := findTOMLKeyValue(content, children.Node(), key, target)
+			if err != nil || span != nil {
+				return span, err
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func tomlKey(it unstable.Iterator) []string {
+	var key []string
+	for it.Next() {
+		key = append(key, string(it.Node().Data))
+	}
+	return key
+}
@@ -0,0 +1,195 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStructuredSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		path       string
+		content    string
+		want       string
+		newVersion string
+		wantAfter  string
+		wantErr    bool
+	}{
+		{
+			name: "json nested key keeps formatting",
+			file: "app.json",
+			path: "expo.version",
+			content: `{
+    "expo": {"name": "app", "version": "1.2.3", "ios": {"version": "0.0.1"}},
+    "version": "9.9.9"
+}
+`,
+			want:       "1.2.3",
+			newVersion: "1.3.0",
+			wantAfter: `{
+    "expo": {"name": "app", "version": "1.3.0", "ios": {"version": "0.0.1"}},
+    "version": "9.9.9"
+}
+`,
+		},
+		{
+			name:       "json array index with jsonpath prefix",
+			file:       "manifest.json",
+			path:       "$.packages[1].version",
+			content:    `{"packages": [{"version": "0.1.0"}, {"version" :  "2.0.0"}]}`,
+			want:       "2.0.0",
+			newVersion: "2.0.1",
+			wantAfter:  `{"packages": [{"version": "0.1.0"}, {"version" :  "2.0.1"}]}`,
+		},
+		{
+			name: "yaml keeps comments and quotes",
+			file: "metadata.yaml",
+			path: "metadata.version",
+			content: `# release metadata
+metadata:
+  name: thing   # the name
+  version: '0.4.0'   # bumped by bumpr
+other:
+  version: 1.0.0
+`,
+			want:       "0.4.0",
+			newVersion: "0.5.0",
+			wantAfter: `# release metadata
+metadata:
+  name: thing   # the name
+  version: '0.5.0'   # bumped by bumpr
+other:
+  version: 1.0.0
+`,
+		},
+		{
+			name:       "yaml plain scalar in sequence",
+			file:       "chart.yml",
+			path:       "entries[0].version",
+			content:    "entries:\n  - name: a\n    version: 3.1.4\n",
+			want:       "3.1.4",
+			newVersion: "3.2.0",
+			wantAfter:  "entries:\n  - name: a\n    version: 3.2.0\n",
+		},
+		{
+			name: "toml table",
+			file: "Cargo.toml",
+			path: "package.version",
+			content: `[package]
+name = "crate"
+version = "0.1.0" # keep me
+
+[dependencies]
+serde = { version = "1.0" }
+`,
+			want:       "0.1.0",
+			newVersion: "0.2.0",
+			wantAfter: `[package]
+name = "crate"
+version = "0.2.0" # keep me
+
+[dependencies]
+serde = { version = "1.0" }
+`,
+		},
+		{
+			name:       "toml inline table and literal string",
+			file:       "config.toml",
+			path:       "dependencies.serde.version",
+			content:    "[dependencies]\nserde = { version = '1.0.0', features = [] }\n",
+			want:       "1.0.0",
+			newVersion: "1.1.0",
+			wantAfter:  "[dependencies]\nserde = { version = '1.1.0', features = [] }\n",
+		},
+		{
+			name:       "toml dotted key",
+			file:       "pyproject.toml",
+			path:       "tool.app.version",
+			content:    "[tool]\napp.version = \"5.0.0\"\n",
+			want:       "5.0.0",
+			newVersion: "6.0.0",
+			wantAfter:  "[tool]\napp.version = \"6.0.0\"\n",
+		},
+		{
+			name:    "missing key",
+			file:    "manifest.json",
+			path:    "version",
+			content: `{"name": "x"}`,
+			wantErr: true,
+		},
+		{
+			name:    "non string value",
+			file:    "manifest.json",
+			path:    "version",
+			content: `{"version": 3}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create temp file: %v", err)
+			}
+
+			source, err := NewStructuredSource(tt.file, tt.path)
+			if err != nil {
+				t.Fatalf("NewStructuredSource() error = %v", err)
+			}
+
+			got, err := source.GetVersion(tmpFile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetVersion() = %v, want %v", got, tt.want)
+			}
+
+			if err := source.SetVersion(tmpFile, tt.newVersion); err != nil {
+				t.Fatalf("SetVersion() error = %v", err)
+			}
+
+			content, err := os.ReadFile(tmpFile)
+			if err != nil {
+				t.Fatalf("failed to read updated file: %v", err)
+			}
+			if string(content) != tt.wantAfter {
+				t.Errorf("SetVersion() content =\n%s\nwant:\n%s", content, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestParseKeyPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{path: "version", want: []string{"version"}},
+		{path: "$.expo.version", want: []string{"expo", "version"}},
+		{path: "packages[0].version", want: []string{"packages", "0", "version"}},
+		{path: "tool['my.app'].version", want: []string{"tool", "my.app", "version"}},
+		{path: "packages[x]", wantErr: true},
+		{path: "$", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := parseKeyPath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseKeyPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("parseKeyPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
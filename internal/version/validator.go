@@ -2,9 +2,15 @@ package version
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
+// strictSemVerRegex is the reference SemVer 2.0.0 expression from semver.org
+var strictSemVerRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
 func Validate(versionStr string) error {
 	if versionStr == "" {
 		return fmt.Errorf("version cannot be empty")
@@ -33,4 +39,13 @@ func NormalizeVersion(versionStr string) (string, error) {
 	}
 
 	return v.String(), nil
-}
\ No newline at end of file
+}
+
+// ValidateStrictSemVer checks versionStr against SemVer 2.0.0 exactly: no "v"
+// prefix and no leading zeros.
+func ValidateStrictSemVer(versionStr string) error {
+	if !strictSemVerRegex.MatchString(versionStr) {
+		return fmt.Errorf("%q is not a valid SemVer 2.0.0 version", versionStr)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestValidateStrictSemVer(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{input: "1.2.3", wantErr: false},
+		{input: "0.0.0", wantErr: false},
+		{input: "1.0.0-alpha.1", wantErr: false},
+		{input: "1.0.0+build.5", wantErr: false},
+		{input: "v1.2.3", wantErr: true},
+		{input: "01.2.3", wantErr: true},
+		{input: "1.2", wantErr: true},
+		{input: "1.0.0-01", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			err := ValidateStrictSemVer(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateStrictSemVer(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}